@@ -1,19 +1,49 @@
 package main
 
 import (
+	"strings"
+
 	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/hints"
+	"github.com/XD637/err/parsers"
 	"github.com/XD637/err/registry"
 
 	// Import all parsers to register them
+	_ "github.com/XD637/err/parsers/dotnet"
+	_ "github.com/XD637/err/parsers/elixir"
 	_ "github.com/XD637/err/parsers/golang"
+	_ "github.com/XD637/err/parsers/haskell"
 	_ "github.com/XD637/err/parsers/javascript"
+	_ "github.com/XD637/err/parsers/jvm"
+	_ "github.com/XD637/err/parsers/nim"
+	_ "github.com/XD637/err/parsers/odin"
+	_ "github.com/XD637/err/parsers/php"
 	_ "github.com/XD637/err/parsers/python"
+	_ "github.com/XD637/err/parsers/ruby"
 	_ "github.com/XD637/err/parsers/rust"
+	_ "github.com/XD637/err/parsers/swift"
+	_ "github.com/XD637/err/parsers/zig"
 )
 
 // Cleaner processes error messages using registered parsers
 type Cleaner struct {
 	format string
+
+	// Root is the project root used to classify stack frames. Empty means
+	// the current directory.
+	Root string
+
+	// Frames selects which stack frames are reported. The zero value
+	// reports all of them.
+	Frames errclean.FrameFilter
+
+	// Snippets enables source code context for the error location, with
+	// Context lines shown before and after the offending line
+	Snippets bool
+	Context  int
+
+	// Hints suggests next steps for each error. Nil disables hints.
+	Hints *hints.Engine
 }
 
 // NewCleaner creates a new error cleaner
@@ -23,31 +53,109 @@ func NewCleaner(format string) *Cleaner {
 
 // Clean processes the error text using the appropriate parser
 func (c *Cleaner) Clean(text string) *errclean.CleanedError {
-	var parser interface {
-		Parse(string) *errclean.CleanedError
+	parser := c.parserFor(text)
+	if parser == nil {
+		// Fallback to generic parsing
+		return genericError(text)
 	}
 
-	if c.format == "auto" {
-		// Auto-detect the best parser
-		parser = registry.DetectParser(text)
-		if parser == nil {
-			// Fallback to generic parsing
-			return &errclean.CleanedError{
-				Type:    "error",
-				Message: errclean.StripNoise(text),
-			}
+	if rooted, ok := parser.(parsers.RootParser); ok {
+		if results := rooted.ParseAllIn(text, c.Root); len(results) > 0 {
+			return c.finish(parser, results[:1])[0]
 		}
-	} else {
-		// Use specified parser
-		parser = registry.GetParser(c.format)
-		if parser == nil {
-			// Fallback to generic parsing
-			return &errclean.CleanedError{
-				Type:    "error",
-				Message: errclean.StripNoise(text),
-			}
+	}
+
+	return c.finish(parser, []*errclean.CleanedError{parser.Parse(text)})[0]
+}
+
+// CleanAll processes the error text and returns every diagnostic found,
+// most relevant first
+func (c *Cleaner) CleanAll(text string) []*errclean.CleanedError {
+	parser := c.parserFor(text)
+	if parser == nil {
+		return []*errclean.CleanedError{genericError(text)}
+	}
+
+	if rooted, ok := parser.(parsers.RootParser); ok {
+		if results := rooted.ParseAllIn(text, c.Root); len(results) > 0 {
+			return c.finish(parser, results)
+		}
+	}
+
+	if multi, ok := parser.(parsers.MultiParser); ok {
+		if results := multi.ParseAll(text); len(results) > 0 {
+			return c.finish(parser, results)
 		}
 	}
 
-	return parser.Parse(text)
+	return c.finish(parser, []*errclean.CleanedError{parser.Parse(text)})
+}
+
+// finish classifies and filters the stack frames of parsed errors and adds
+// source snippets and hints
+func (c *Cleaner) finish(parser parsers.Parser, results []*errclean.CleanedError) []*errclean.CleanedError {
+	classifier := errclean.NewClassifier(c.Root)
+	for _, result := range results {
+		result.Language = parser.Name()
+		classifier.ClassifyFrames(result.Stack)
+		if c.Snippets {
+			result.Source = c.snippet(result, classifier)
+		}
+		if c.Hints != nil {
+			result.Hints = c.Hints.Suggest(result)
+		}
+		result.Stack = c.Frames.Apply(result.Stack)
+	}
+	return results
+}
+
+// snippet reads the source around the error location, or around the first
+// user frame if the location is not in project code
+func (c *Cleaner) snippet(result *errclean.CleanedError, classifier *errclean.Classifier) *errclean.Snippet {
+	candidates := []errclean.Frame{{Location: result.Location}}
+	candidates = append(candidates, result.Stack...)
+
+	for _, frame := range candidates {
+		if frame.Location.IsZero() || classifier.Kind(frame) != errclean.FrameUser {
+			continue
+		}
+		if snippet := errclean.ReadSnippet(classifier.Root(), frame.Location, c.Context); snippet != nil {
+			return snippet
+		}
+	}
+	return nil
+}
+
+// parserFor returns the parser selected by the format, or nil if none applies
+func (c *Cleaner) parserFor(text string) parsers.Parser {
+	if c.format == "auto" {
+		// Auto-detect the best parser
+		return registry.DetectParser(text)
+	}
+
+	// Use specified parser
+	return registry.GetParser(c.format)
+}
+
+// formatNames returns the accepted -format values: "auto" followed by
+// the name of every registered parser
+func formatNames() []string {
+	names := []string{"auto"}
+	for _, parser := range registry.AllParsers() {
+		names = append(names, parser.Name())
+	}
+	return names
+}
+
+// formatUsage is the usage text shared by every -format flag
+func formatUsage() string {
+	return "error format (" + strings.Join(formatNames(), "|") + ")"
+}
+
+// genericError is used when no parser matches the input
+func genericError(text string) *errclean.CleanedError {
+	return &errclean.CleanedError{
+		Type:    "error",
+		Message: errclean.StripNoise(text),
+	}
 }
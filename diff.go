@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/XD637/err/errclean"
+)
+
+// runDiff is the result of comparing two runs
+type runDiff struct {
+	Added      []*errclean.CleanedError
+	Resolved   []*errclean.CleanedError
+	Persisting []*errclean.CleanedError // As reported in the second run
+}
+
+// diffEntry is a diagnostic in the JSON output
+type diffEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Type        string `json:"type"`
+	Message     string `json:"message"`
+	Severity    string `json:"severity"`
+	Test        string `json:"test,omitempty"`
+	File        string `json:"file,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Column      int    `json:"column,omitempty"`
+}
+
+// runDiffCommand implements `err diff before.log after.log`. It exits
+// with 1 when the second run adds errors, so it can gate CI or drive
+// `git bisect run`.
+func runDiffCommand(args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	format := fs.String("format", "auto", formatUsage())
+	output := fs.String("output", "text", "output format (text|json)")
+	minSev := fs.String("min-severity", "warning", "lowest severity to compare (error|warning|note|help)")
+	root := fs.String("root", "", "project root used to classify frames (default: current directory)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: err diff [-output text|json] before.log after.log")
+		return 2
+	}
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "error: unknown output format %q (want text or json)\n", *output)
+		return 2
+	}
+	minSeverity, err := errclean.ParseSeverity(*minSev)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+
+	cleaner := NewCleaner(*format)
+	cleaner.Root = *root
+
+	var runs [2][]*errclean.CleanedError
+	for i, path := range fs.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 2
+		}
+		runs[i] = diffResults(cleaner, string(data), minSeverity)
+	}
+
+	diff := diffRuns(runs[0], runs[1])
+	if *output == "json" {
+		printDiffJSON(diff)
+	} else {
+		printDiffText(os.Stdout, diff, stdoutIsTerminal())
+	}
+
+	if len(diff.Added) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// diffResults returns the diagnostics of a log. Text no parser recognizes,
+// such as the output of a passing build, is not an error: a log without
+// errors resolves every error of the other one.
+func diffResults(cleaner *Cleaner, log string, minSeverity errclean.Severity) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	for _, result := range errclean.FilterSeverity(cleaner.CleanAll(log), minSeverity) {
+		if result.Language == "" {
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// diffRuns matches the diagnostics of two runs by fingerprint. Repeated
+// diagnostics are matched one for one, so a third copy of an error is
+// reported as added.
+func diffRuns(before, after []*errclean.CleanedError) runDiff {
+	unmatched := make(map[string][]*errclean.CleanedError)
+	for _, result := range before {
+		fingerprint := result.Fingerprint()
+		unmatched[fingerprint] = append(unmatched[fingerprint], result)
+	}
+
+	var diff runDiff
+	for _, result := range after {
+		fingerprint := result.Fingerprint()
+		if len(unmatched[fingerprint]) > 0 {
+			unmatched[fingerprint] = unmatched[fingerprint][1:]
+			diff.Persisting = append(diff.Persisting, result)
+		} else {
+			diff.Added = append(diff.Added, result)
+		}
+	}
+
+	// Keep the first run's order for resolved errors
+	for _, result := range before {
+		fingerprint := result.Fingerprint()
+		if len(unmatched[fingerprint]) > 0 && unmatched[fingerprint][0] == result {
+			unmatched[fingerprint] = unmatched[fingerprint][1:]
+			diff.Resolved = append(diff.Resolved, result)
+		}
+	}
+	return diff
+}
+
+// summary is a one-line description suitable for a PR comment
+func (d runDiff) summary() string {
+	return fmt.Sprintf("%d added, %d resolved, %d persisting", len(d.Added), len(d.Resolved), len(d.Persisting))
+}
+
+// printDiffText writes the summary and one line per diagnostic, grouped
+// by section. Colors are only used when color is set, so redirected
+// output stays plain text.
+func printDiffText(w io.Writer, diff runDiff, color bool) {
+	paint := func(code string) string {
+		if !color {
+			return ""
+		}
+		return code
+	}
+
+	fmt.Fprintln(w, diff.summary())
+
+	sections := []struct {
+		title   string
+		marker  string
+		color   string
+		results []*errclean.CleanedError
+	}{
+		{"Added", "+", colorRed, diff.Added},
+		{"Resolved", "-", colorGreen, diff.Resolved},
+		{"Persisting", "=", colorGray, diff.Persisting},
+	}
+
+	for _, section := range sections {
+		if len(section.results) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s%s:%s\n", paint(colorBold), section.title, paint(colorReset))
+		for _, result := range section.results {
+			fmt.Fprintf(w, "%s%s %s%s\n", paint(section.color), section.marker, diffLine(result), paint(colorReset))
+		}
+	}
+}
+
+// diffLine formats a diagnostic on one line: "src/main.rs:5:20: E0382: message"
+func diffLine(result *errclean.CleanedError) string {
+	line := result.Type + ": " + result.Message
+	if result.Test != "" {
+		line = result.Test + ": " + line
+	}
+	if loc := result.PrimaryLocation(); !loc.IsZero() {
+		line = loc.String() + ": " + line
+	}
+	return line
+}
+
+func printDiffJSON(diff runDiff) {
+	entries := func(results []*errclean.CleanedError) []diffEntry {
+		list := make([]diffEntry, 0, len(results))
+		for _, result := range results {
+			loc := result.PrimaryLocation()
+			list = append(list, diffEntry{
+				Fingerprint: result.Fingerprint(),
+				Type:        result.Type,
+				Message:     result.Message,
+				Severity:    result.Severity.String(),
+				Test:        result.Test,
+				File:        loc.File,
+				Line:        loc.Line,
+				Column:      loc.Column,
+			})
+		}
+		return list
+	}
+
+	report := map[string]any{
+		"summary": map[string]int{
+			"added":      len(diff.Added),
+			"resolved":   len(diff.Resolved),
+			"persisting": len(diff.Persisting),
+		},
+		"added":      entries(diff.Added),
+		"resolved":   entries(diff.Resolved),
+		"persisting": entries(diff.Persisting),
+	}
+
+	data, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(data))
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestDiffRuns(t *testing.T) {
+	ts := func(file string, line int, code, message string) *errclean.CleanedError {
+		return &errclean.CleanedError{Type: code, Message: message, Location: errclean.Location{File: file, Line: line}}
+	}
+
+	before := []*errclean.CleanedError{
+		ts("src/a.ts", 3, "TS2322", "Type 'string' is not assignable to type 'number'."),
+		ts("src/a.ts", 9, "TS2322", "Type 'string' is not assignable to type 'number'."),
+		ts("src/b.ts", 1, "TS2307", "Cannot find module 'lodash'"),
+	}
+	after := []*errclean.CleanedError{
+		// Same error, moved down two lines
+		ts("src/a.ts", 5, "TS2322", "Type 'string' is not assignable to type 'number'."),
+		ts("src/c.ts", 7, "TS2304", "Cannot find name 'foo'."),
+		ts("src/c.ts", 8, "TS2304", "Cannot find name 'bar'."),
+	}
+
+	diff := diffRuns(before, after)
+	if got := diff.summary(); got != "2 added, 2 resolved, 1 persisting" {
+		t.Errorf("summary() = %q", got)
+	}
+
+	if diff.Persisting[0].Location.Line != 5 {
+		t.Errorf("persisting error should be reported at its new line, got %d", diff.Persisting[0].Location.Line)
+	}
+	if diff.Resolved[0].Location.Line != 9 || diff.Resolved[1].Type != "TS2307" {
+		t.Errorf("resolved = %s:%d, %s", diff.Resolved[0].Type, diff.Resolved[0].Location.Line, diff.Resolved[1].Type)
+	}
+	if diff.Added[0].Message != "Cannot find name 'foo'." || diff.Added[1].Message != "Cannot find name 'bar'." {
+		t.Errorf("added = %q, %q", diff.Added[0].Message, diff.Added[1].Message)
+	}
+}
+
+func TestPrintDiffTextColor(t *testing.T) {
+	diff := runDiff{
+		Added:    []*errclean.CleanedError{{Type: "E0382", Message: "borrow of moved value: `s`"}},
+		Resolved: []*errclean.CleanedError{{Type: "E0425", Message: "cannot find value `x` in this scope"}},
+	}
+
+	var plain strings.Builder
+	printDiffText(&plain, diff, false)
+	if strings.Contains(plain.String(), "\033[") {
+		t.Errorf("plain output contains color codes:\n%q", plain.String())
+	}
+	if !strings.Contains(plain.String(), "+ E0382: borrow of moved value: `s`\n") {
+		t.Errorf("plain output = %q", plain.String())
+	}
+
+	var colored strings.Builder
+	printDiffText(&colored, diff, true)
+	if !strings.Contains(colored.String(), colorRed+"+ E0382") {
+		t.Errorf("colored output = %q", colored.String())
+	}
+}
+
+func TestDiffCleanLog(t *testing.T) {
+	cleaner := NewCleaner("auto")
+	before := diffResults(cleaner, `./main.go:8:2: undefined: x
+./main.go:12:9: cannot use s (variable of type string) as int value in return statement`, errclean.SeverityWarning)
+	if len(before) != 2 {
+		t.Fatalf("expected 2 errors before, got %d", len(before))
+	}
+
+	for _, log := range []string{"", "ok  \tgithub.com/me/app\t0.012s\n"} {
+		diff := diffRuns(before, diffResults(cleaner, log, errclean.SeverityWarning))
+		if got := diff.summary(); got != "0 added, 2 resolved, 0 persisting" {
+			t.Errorf("summary() for %q = %q", log, got)
+		}
+	}
+}
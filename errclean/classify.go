@@ -0,0 +1,145 @@
+package errclean
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Directories that hold third-party code, matched as path segments
+var dependencyDirs = []string{
+	"node_modules/",
+	"bower_components/",
+	"site-packages/",
+	"dist-packages/",
+	"vendor/",
+	".cargo/registry/",
+	".cargo/git/",
+	"pkg/mod/",
+}
+
+// Path fragments of language runtimes and standard libraries
+var runtimePaths = []string{
+	"node:",          // Node.js built-in modules: node:internal/timers
+	"<anonymous>",    // eval'd or native code
+	"/rustc/",        // Rust standard library sources
+	"<frozen ",       // Python frozen modules: <frozen importlib._bootstrap>
+	"/usr/local/go/", // Go standard library (default GOROOT)
+	"/usr/lib/go/",
+}
+
+// Function name prefixes of language runtimes and standard libraries
+var runtimeFunctions = []string{
+	"std::", "core::", "alloc::", "rust_begin_unwind", "__rust",
+	"runtime.", "testing.",
+	"java.", "javax.", "jdk.", "sun.", "kotlin.", "scala.", // JVM standard libraries
+	"base:", "ghc-internal:", "ghc-prim:", // GHC boot packages, named as package:Module in call stacks
+}
+
+// Function name prefixes of widely used frameworks. JVM frames only name
+// their source file, not its path, so the package decides.
+var dependencyFunctions = []string{
+	"org.junit.", "org.gradle.", "org.apache.", "org.springframework.", "org.hibernate.",
+	"kotlinx.", "akka.", "io.netty.",
+}
+
+// Classifier tags stack frames as user, dependency or runtime code
+type Classifier struct {
+	root string
+}
+
+// NewClassifier creates a classifier for the project at root. An empty
+// root means the current directory.
+func NewClassifier(root string) *Classifier {
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	return &Classifier{root: root}
+}
+
+// Root returns the absolute project root
+func (c *Classifier) Root() string {
+	return c.root
+}
+
+// ClassifyFrames sets the Kind of every frame
+func (c *Classifier) ClassifyFrames(frames []Frame) {
+	for i := range frames {
+		frames[i].Kind = c.Kind(frames[i])
+	}
+}
+
+// Kind returns where the code in a frame comes from. A kind the parser
+// already set is kept: module names like Agent. or Task. only mean the
+// standard library in the language the parser knows the frame is from.
+func (c *Classifier) Kind(frame Frame) FrameKind {
+	if frame.Kind != FrameUser {
+		return frame.Kind
+	}
+	for _, prefix := range runtimeFunctions {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return FrameRuntime
+		}
+	}
+	for _, prefix := range dependencyFunctions {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return FrameDependency
+		}
+	}
+
+	file := frame.Location.File
+	if file == "" {
+		file = frame.Text
+	}
+	file = filepath.ToSlash(file)
+
+	// Only the part below the project root decides, so a project that
+	// lives in e.g. ~/vendor/app is still user code
+	if c.root != "" && filepath.IsAbs(filepath.FromSlash(file)) {
+		if rel, err := filepath.Rel(c.root, filepath.FromSlash(file)); err == nil && !strings.HasPrefix(rel, "..") {
+			file = filepath.ToSlash(rel)
+		}
+	}
+
+	segmented := "/" + strings.TrimPrefix(file, "./")
+	for _, dir := range dependencyDirs {
+		if strings.Contains(segmented, "/"+dir) {
+			return FrameDependency
+		}
+	}
+
+	for _, fragment := range runtimePaths {
+		if strings.Contains(file, fragment) {
+			return FrameRuntime
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(file, "internal/") && strings.HasSuffix(file, ".js"):
+		// Node.js internals before node: prefixes: internal/modules/cjs/loader.js
+		return FrameRuntime
+	case strings.Contains(strings.ToLower(file), "/lib/python"):
+		// Python standard library: /usr/lib/python3.11/json/decoder.py
+		return FrameRuntime
+	case isGoStdlib(file):
+		return FrameRuntime
+	}
+
+	return FrameUser
+}
+
+// isGoStdlib reports whether a path is in a GOROOT-style src directory.
+// GOPATH code lives below a domain (go/src/github.com/...), the standard
+// library does not (go/src/net/http/server.go).
+func isGoStdlib(file string) bool {
+	i := strings.Index(file, "/go/src/")
+	if i < 0 || !strings.HasSuffix(file, ".go") {
+		return false
+	}
+
+	first := strings.SplitN(file[i+len("/go/src/"):], "/", 2)[0]
+	return !strings.Contains(first, ".")
+}
@@ -6,27 +6,85 @@ import (
 
 // CleanedError represents a normalized error
 type CleanedError struct {
-	Type    string
-	Message string
-	Stack   []string
+	Type     string
+	Message  string
+	Severity Severity
+	Location Location // Primary source location, if known
+	Test     string   // Name of the failing test, if any
+	Details  []string // Extra context such as expected/received values
+	Hints    []string // Suggested next steps
+	Language string   // Name of the parser that produced the error
+	Source   *Snippet // Source code around the error, if the file exists
+	Stack    []Frame
 }
 
 // ANSI color codes
 const (
-	colorReset = "\033[0m"
-	colorRed   = "\033[31m"
-	colorGray  = "\033[90m"
-	colorBold  = "\033[1m"
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorCyan   = "\033[36m"
+	colorGray   = "\033[90m"
+	colorBold   = "\033[1m"
 )
 
+// PrimaryLocation returns the location to jump to for this error: its
+// Location, or else the first user frame with a location
+func (e *CleanedError) PrimaryLocation() Location {
+	if !e.Location.IsZero() {
+		return e.Location
+	}
+	for _, frame := range e.Stack {
+		if frame.Kind == FrameUser && !frame.Location.IsZero() {
+			return frame.Location
+		}
+	}
+	return Location{}
+}
+
+// label returns the type shown before the message. Color is lost when
+// output is piped, so anything less than an error names its severity:
+// "warning[unused_variables]".
+func (e *CleanedError) label() string {
+	if e.Severity == SeverityError || e.Type == e.Severity.String() {
+		return e.Type
+	}
+	if e.Type == "" {
+		return e.Severity.String()
+	}
+	return e.Severity.String() + "[" + e.Type + "]"
+}
+
 // Format returns a human-readable representation with colors
 func (e *CleanedError) Format() string {
+	return e.FormatWith(FormatOptions{})
+}
+
+// FormatWith returns a human-readable representation with colors and,
+// if configured, hyperlinked frame locations
+func (e *CleanedError) FormatWith(opts FormatOptions) string {
 	var sb strings.Builder
 
-	if e.Type != "" {
-		sb.WriteString(colorRed)
+	color := colorRed
+	switch e.Severity {
+	case SeverityWarning:
+		color = colorYellow
+	case SeverityNote, SeverityHelp:
+		color = colorCyan
+	}
+
+	if e.Test != "" {
 		sb.WriteString(colorBold)
-		sb.WriteString(e.Type)
+		sb.WriteString("● ")
+		sb.WriteString(e.Test)
+		sb.WriteString(colorReset)
+		sb.WriteString("\n")
+	}
+
+	if label := e.label(); label != "" {
+		sb.WriteString(color)
+		sb.WriteString(colorBold)
+		sb.WriteString(label)
 		sb.WriteString(colorReset)
 		if e.Message != "" {
 			sb.WriteString(": ")
@@ -34,21 +92,51 @@ func (e *CleanedError) Format() string {
 	}
 
 	if e.Message != "" {
-		sb.WriteString(colorRed)
+		sb.WriteString(color)
 		sb.WriteString(e.Message)
 		sb.WriteString(colorReset)
 	}
 
-	if len(e.Stack) > 0 {
+	if len(e.Details) > 0 || len(e.Hints) > 0 || len(e.Stack) > 0 || e.Source != nil {
 		sb.WriteString("\n")
-		for _, frame := range e.Stack {
+	}
+
+	if e.Source != nil {
+		for _, line := range e.Source.Format() {
 			sb.WriteString(colorGray)
 			sb.WriteString("  ")
-			sb.WriteString(frame)
+			sb.WriteString(line)
 			sb.WriteString(colorReset)
 			sb.WriteString("\n")
 		}
 	}
 
+	for _, detail := range e.Details {
+		sb.WriteString("  ")
+		sb.WriteString(detail)
+		sb.WriteString("\n")
+	}
+
+	for _, hint := range e.Hints {
+		sb.WriteString(colorCyan)
+		sb.WriteString("  → ")
+		sb.WriteString(hint)
+		sb.WriteString(colorReset)
+		sb.WriteString("\n")
+	}
+
+	for _, frame := range e.Stack {
+		text := frame.Text
+		if opts.LinkTemplate != "" && !frame.Location.IsZero() {
+			text = Hyperlink(text, LinkURL(opts.LinkTemplate, opts.Root, frame.Location))
+		}
+
+		sb.WriteString(colorGray)
+		sb.WriteString("  ")
+		sb.WriteString(text)
+		sb.WriteString(colorReset)
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
@@ -0,0 +1,31 @@
+package errclean
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFormatLabelsSeverity(t *testing.T) {
+	ansi := regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+	tests := []struct {
+		name     string
+		err      CleanedError
+		expected string
+	}{
+		{"Error", CleanedError{Type: "E0382", Message: "borrow of moved value"}, "E0382: borrow of moved value"},
+		{"Warning with a lint", CleanedError{Type: "unused_variables", Message: "unused variable", Severity: SeverityWarning}, "warning[unused_variables]: unused variable"},
+		{"Warning named by level", CleanedError{Type: "warning", Message: "unused import", Severity: SeverityWarning}, "warning: unused import"},
+		{"Note without a type", CleanedError{Message: "defined here", Severity: SeverityNote}, "note: defined here"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.TrimSpace(ansi.ReplaceAllString(tt.err.Format(), ""))
+			if got != tt.expected {
+				t.Errorf("Format() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
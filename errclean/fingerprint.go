@@ -0,0 +1,21 @@
+package errclean
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"path/filepath"
+)
+
+// Fingerprint identifies an error across runs. It covers the type,
+// message, test and file but not the line, so an error keeps its
+// fingerprint when code above it moves.
+func (e *CleanedError) Fingerprint() string {
+	file := filepath.ToSlash(e.PrimaryLocation().File)
+
+	h := sha1.New()
+	for _, part := range []string{e.Type, e.Message, e.Test, file} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
@@ -0,0 +1,127 @@
+package errclean
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FrameKind classifies where the code in a stack frame comes from
+type FrameKind int
+
+const (
+	// FrameUser is project code. It is the zero value, so frames that
+	// cannot be classified stay visible.
+	FrameUser FrameKind = iota
+	// FrameDependency is third-party code (node_modules, site-packages, ...)
+	FrameDependency
+	// FrameRuntime is the language runtime or standard library
+	FrameRuntime
+)
+
+var frameKindNames = []string{"user", "dependency", "runtime"}
+
+// String returns the lowercase kind name
+func (k FrameKind) String() string {
+	if k < 0 || int(k) >= len(frameKindNames) {
+		return "unknown"
+	}
+	return frameKindNames[k]
+}
+
+// Frame is a single stack frame or source location
+type Frame struct {
+	Text     string   // Display form with noise removed
+	Function string   // Function or method name, if known
+	Location Location // Location with the full path, used to find the file
+	Kind     FrameKind
+}
+
+// NewFrame creates a frame from its printed text and location
+func NewFrame(text string, loc Location) Frame {
+	return Frame{Text: StripNoise(text), Location: loc}
+}
+
+// String returns the display form of the frame
+func (f Frame) String() string {
+	return f.Text
+}
+
+// FrameFilter selects which stack frames are reported
+type FrameFilter struct {
+	UserOnly bool // Collapse runs of dependency and runtime frames
+	Limit    int  // Report at most this many frames; 0 for no limit
+}
+
+// ParseFrameFilter parses a -frames value: "user", "all" or a frame count
+func ParseFrameFilter(s string) (FrameFilter, error) {
+	switch s {
+	case "user":
+		return FrameFilter{UserOnly: true}, nil
+	case "all":
+		return FrameFilter{}, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return FrameFilter{}, fmt.Errorf("invalid frames value %q (want user, all or a positive number)", s)
+	}
+	return FrameFilter{Limit: n}, nil
+}
+
+// Apply returns the frames to report. Hidden frames are replaced by a
+// marker such as "… 7 dependency frames".
+func (f FrameFilter) Apply(frames []Frame) []Frame {
+	if f.UserOnly {
+		frames = collapseNonUser(frames)
+	}
+
+	if f.Limit > 0 && len(frames) > f.Limit {
+		hidden := len(frames) - f.Limit
+		frames = append(frames[:f.Limit:f.Limit], Frame{Text: frameMarker(hidden, "more")})
+	}
+
+	return frames
+}
+
+// collapseNonUser replaces each run of dependency or runtime frames with a
+// marker. Stacks without user frames are returned unchanged, since there
+// is nothing better to show.
+func collapseNonUser(frames []Frame) []Frame {
+	hasUser := false
+	for _, frame := range frames {
+		if frame.Kind == FrameUser {
+			hasUser = true
+			break
+		}
+	}
+	if !hasUser {
+		return frames
+	}
+
+	var result []Frame
+	for i := 0; i < len(frames); {
+		if frames[i].Kind == FrameUser {
+			result = append(result, frames[i])
+			i++
+			continue
+		}
+
+		kind := frames[i].Kind
+		j := i
+		for j < len(frames) && frames[j].Kind == kind {
+			j++
+		}
+		result = append(result, Frame{Text: frameMarker(j-i, kind.String()), Kind: kind})
+		i = j
+	}
+
+	return result
+}
+
+// frameMarker describes hidden frames, e.g. "… 7 dependency frames"
+func frameMarker(count int, kind string) string {
+	if count == 1 {
+		return fmt.Sprintf("… 1 %s frame", kind)
+	}
+	return fmt.Sprintf("… %d %s frames", count, kind)
+}
@@ -0,0 +1,91 @@
+package errclean
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClassifierKind(t *testing.T) {
+	root := filepath.FromSlash("/home/dev/app")
+	classifier := NewClassifier(root)
+
+	tests := []struct {
+		name     string
+		frame    Frame
+		expected FrameKind
+	}{
+		{"Project file", Frame{Location: Location{File: "/home/dev/app/src/index.js"}}, FrameUser},
+		{"Relative file", Frame{Location: Location{File: "src/main.rs"}}, FrameUser},
+		{"node_modules", Frame{Location: Location{File: "/home/dev/app/node_modules/express/lib/router/layer.js"}}, FrameDependency},
+		{"Node internals", Frame{Location: Location{File: "internal/modules/cjs/loader.js"}}, FrameRuntime},
+		{"Node built-in", Frame{Location: Location{File: "node:internal/timers"}}, FrameRuntime},
+		{"site-packages", Frame{Location: Location{File: "/usr/lib/python3/site-packages/requests/api.py"}}, FrameDependency},
+		{"Python stdlib", Frame{Location: Location{File: "/usr/lib/python3.11/json/decoder.py"}}, FrameRuntime},
+		{"Go module cache", Frame{Location: Location{File: "/home/dev/go/pkg/mod/github.com/lib/pq@v1.10.0/conn.go"}}, FrameDependency},
+		{"Go stdlib", Frame{Location: Location{File: "/usr/local/go/src/net/http/server.go"}}, FrameRuntime},
+		{"GOPATH project", Frame{Location: Location{File: "/Users/dev/go/src/github.com/me/app/main.go"}}, FrameUser},
+		{"Go runtime function", Frame{Function: "runtime.gopanic"}, FrameRuntime},
+		{"Rust std function", Frame{Function: "core::panicking::panic_fmt"}, FrameRuntime},
+		{"Cargo registry", Frame{Location: Location{File: "/home/dev/.cargo/registry/src/serde-1.0/src/de.rs"}}, FrameDependency},
+		{"Vendor dir outside root", Frame{Location: Location{File: "/srv/vendor/app/main.go"}}, FrameDependency},
+		{"Project inside a vendor dir", Frame{Location: Location{File: "/home/dev/app/src/vendor.go"}}, FrameUser},
+		{"JS function named like an Elixir module", Frame{Function: "Agent.run", Location: Location{File: "/home/dev/app/src/agent.js"}}, FrameUser},
+		{"Python module named like a Swift library", Frame{Location: Location{File: "/home/dev/app/src/libswift_bridge.py"}}, FrameUser},
+		{"Python package named like the Nim stdlib", Frame{Location: Location{File: "/home/dev/app/src/lib/system/run.py"}}, FrameUser},
+		{"JS module named like the Zig stdlib", Frame{Location: Location{File: "/home/dev/app/src/lib/std/util.js"}}, FrameUser},
+		{"JS folder named like a gem dir", Frame{Location: Location{File: "/home/dev/app/src/gems/card.js"}}, FrameUser},
+		{"TypeScript namespace named System", Frame{Function: "System.Config.load", Location: Location{File: "/home/dev/app/src/config.ts"}}, FrameUser},
+		{"Kind set by the parser", Frame{Function: "Agent.run/2", Kind: FrameRuntime, Location: Location{File: "lib/agent.ex"}}, FrameRuntime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifier.Kind(tt.frame); got != tt.expected {
+				t.Errorf("Kind() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFrameFilter(t *testing.T) {
+	frames := []Frame{
+		{Text: "a", Kind: FrameUser},
+		{Text: "b", Kind: FrameDependency},
+		{Text: "c", Kind: FrameDependency},
+		{Text: "d", Kind: FrameUser},
+		{Text: "e", Kind: FrameRuntime},
+	}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"user", "a|… 2 dependency frames|d|… 1 runtime frame"},
+		{"all", "a|b|c|d|e"},
+		{"2", "a|b|… 3 more frames"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			filter, err := ParseFrameFilter(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			input := append([]Frame(nil), frames...)
+			var texts []string
+			for _, frame := range filter.Apply(input) {
+				texts = append(texts, frame.Text)
+			}
+
+			if got := strings.Join(texts, "|"); got != tt.expected {
+				t.Errorf("Apply() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := ParseFrameFilter("none"); err == nil {
+		t.Error("ParseFrameFilter(\"none\") should fail")
+	}
+}
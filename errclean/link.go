@@ -0,0 +1,47 @@
+package errclean
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FormatOptions controls how Format renders a cleaned error
+type FormatOptions struct {
+	// LinkTemplate wraps frame locations in OSC 8 terminal hyperlinks.
+	// It may contain {abs}, {file}, {line} and {col}, e.g.
+	// "vscode://file/{abs}:{line}:{col}". Empty disables hyperlinks.
+	LinkTemplate string
+
+	// Root resolves relative paths for {abs}. Empty means the current
+	// directory.
+	Root string
+}
+
+// AbsPath returns the absolute path of a file reported relative to root
+func AbsPath(root, file string) string {
+	path := filepath.FromSlash(file)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// LinkURL expands a link template for a location
+func LinkURL(template, root string, loc Location) string {
+	abs := filepath.ToSlash(AbsPath(root, loc.File))
+	return strings.NewReplacer(
+		"{abs}", abs,
+		"{file}", loc.File,
+		"{line}", strconv.Itoa(loc.Line),
+		"{col}", strconv.Itoa(loc.Column),
+	).Replace(template)
+}
+
+// Hyperlink wraps text in an OSC 8 hyperlink to url
+func Hyperlink(text, url string) string {
+	return "\033]8;;" + url + "\033\\" + text + "\033]8;;\033\\"
+}
@@ -0,0 +1,49 @@
+package errclean
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Location identifies a position in a source file
+type Location struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// "path/to/file.rs:12:5" or "path/to/file.py:12"
+var locationPattern = regexp.MustCompile(`^(.+?):(\d+)(?::(\d+))?$`)
+
+// ParseLocation parses a "file:line[:col]" string. It returns a zero
+// Location if the string is not in that form.
+func ParseLocation(s string) Location {
+	matches := locationPattern.FindStringSubmatch(s)
+	if matches == nil {
+		return Location{}
+	}
+
+	line, _ := strconv.Atoi(matches[2])
+	col, _ := strconv.Atoi(matches[3])
+	return Location{File: matches[1], Line: line, Column: col}
+}
+
+// IsZero reports whether the location is unknown
+func (l Location) IsZero() bool {
+	return l.File == ""
+}
+
+// String returns the location as "file:line:col", omitting unknown parts
+func (l Location) String() string {
+	switch {
+	case l.IsZero():
+		return ""
+	case l.Line == 0:
+		return l.File
+	case l.Column == 0:
+		return fmt.Sprintf("%s:%d", l.File, l.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+	}
+}
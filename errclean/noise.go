@@ -3,6 +3,7 @@ package errclean
 import (
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // Noise removal patterns
@@ -19,6 +20,9 @@ var (
 	// Hex values: 0xdeadbeef
 	hexPattern = regexp.MustCompile(`0x[0-9a-fA-F]+`)
 
+	// BEAM process identifiers: #PID<0.150.0>, <0.150.0>
+	pidPattern = regexp.MustCompile(`(?:#PID)?<\d+\.\d+\.\d+>`)
+
 	// Absolute paths (simplified - keep relative paths)
 	absPathPattern = regexp.MustCompile(`(?:^|[\s(])((?:[A-Z]:\\|/)[^\s:)]+)`)
 )
@@ -29,6 +33,7 @@ func StripNoise(text string) string {
 	text = uuidPattern.ReplaceAllString(text, "[UUID]")
 	text = memoryPattern.ReplaceAllString(text, "[ADDR]")
 	text = hexPattern.ReplaceAllString(text, "[HEX]")
+	text = pidPattern.ReplaceAllString(text, "[PID]")
 
 	// Simplify paths - keep filename only
 	text = absPathPattern.ReplaceAllStringFunc(text, func(match string) string {
@@ -48,15 +53,114 @@ func StripNoise(text string) string {
 	return text
 }
 
+// TruncateTerms shortens data structures longer than limit characters,
+// such as the Erlang terms and Elixir maps that BEAM errors embed. The
+// start of the term is kept and the brackets that were open are closed:
+// {error,{badmatch,{user,42…}}}
+func TruncateTerms(text string, limit int) string {
+	var sb strings.Builder
+	for i := 0; i < len(text); {
+		if text[i] != '{' && text[i] != '[' {
+			sb.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		end := termEnd(text, i)
+		if end-i <= limit {
+			sb.WriteString(text[i:end])
+		} else {
+			sb.WriteString(truncateTerm(text[i:end], limit))
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// termEnd returns the index after the bracket that closes the one at
+// start, or the end of text if it is never closed
+func termEnd(text string, start int) int {
+	depth := 0
+	inString := false
+	for i := start; i < len(text); i++ {
+		switch c := text[i]; {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(text)
+}
+
+// truncateTerm cuts a term after at most limit characters, at the end of
+// an element where possible, and closes the brackets open at that point
+func truncateTerm(term string, limit int) string {
+	var open, openAtBreak []byte
+	inString := false
+	cut, lastBreak := 0, 0
+	for ; cut < len(term) && cut < limit; cut++ {
+		c := term[cut]
+		switch {
+		case inString:
+			if c == '\\' {
+				cut++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{':
+			open = append(open, '}')
+		case c == '[':
+			open = append(open, ']')
+		case c == '}' || c == ']':
+			open = open[:len(open)-1]
+		case c == ',' || c == ' ':
+			lastBreak = cut
+			openAtBreak = append(openAtBreak[:0], open...)
+		}
+	}
+
+	// Back up to the last separator rather than end mid-element
+	if cut < len(term) && !inString && !strings.ContainsRune(", }]", rune(term[cut])) && lastBreak > 0 {
+		cut, open = lastBreak, openAtBreak
+	}
+	for cut < len(term) && !utf8.RuneStart(term[cut]) {
+		cut++
+	}
+
+	text := strings.TrimRight(term[:cut], ", ")
+	if inString {
+		text += `"`
+	}
+	text += "…"
+	for i := len(open) - 1; i >= 0; i-- {
+		text += string(open[i])
+	}
+	return text
+}
+
 // DeduplicateFrames removes consecutive duplicate stack frames
-func DeduplicateFrames(frames []string) []string {
+func DeduplicateFrames(frames []Frame) []Frame {
 	if len(frames) == 0 {
 		return frames
 	}
 
-	result := []string{frames[0]}
+	result := []Frame{frames[0]}
 	for i := 1; i < len(frames); i++ {
-		if frames[i] != frames[i-1] {
+		if frames[i].Text != frames[i-1].Text {
 			result = append(result, frames[i])
 		}
 	}
@@ -0,0 +1,35 @@
+package errclean
+
+import "testing"
+
+func TestTruncateTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		limit    int
+		expected string
+	}{
+		{"Short term", "no match of right hand side value {error,enoent}", 20, "no match of right hand side value {error,enoent}"},
+		{"Nested tuple", "{error,{badmatch,{user,42,<<\"jane\">>}}}", 24, "{error,{badmatch,{user…}}}"},
+		{"Elixir map", "key :id not found in: %{name: \"Jane\", email: \"jane@example.com\"}", 20, "key :id not found in: %{name: \"Jane\"…}"},
+		{"Cut inside a string", `[{"a long string value"}]`, 8, `[{"a lon"…}]`},
+		{"Brackets in strings", `{"}}}}", ok}`, 20, `{"}}}}", ok}`},
+		{"Several terms", "got [1,2,3,4,5,6] and {a,b,c,d,e,f}", 8, "got [1,2,3,4…] and {a,b,c,d…}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateTerms(tt.input, tt.limit); got != tt.expected {
+				t.Errorf("TruncateTerms() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripNoisePIDs(t *testing.T) {
+	input := "GenServer #PID<0.150.0> terminating, linked to <0.120.0>"
+	expected := "GenServer [PID] terminating, linked to [PID]"
+	if got := StripNoise(input); got != expected {
+		t.Errorf("StripNoise() = %q, want %q", got, expected)
+	}
+}
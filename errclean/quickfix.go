@@ -0,0 +1,49 @@
+package errclean
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// QuickfixLine renders the error as a single "file:line:col: type: message"
+// line for Vim's quickfix list and Emacs compilation-mode. Paths are made
+// relative to root unless absolute is set. Errors without a location are
+// rendered without the file prefix.
+func (e *CleanedError) QuickfixLine(root string, absolute bool) string {
+	message := e.Message
+	if e.Type != "" && e.Type != e.Severity.String() {
+		message = e.Type + ": " + message
+	}
+	if e.Test != "" {
+		message = e.Test + ": " + message
+	}
+	message = strings.Join(strings.Fields(message), " ")
+
+	loc := e.PrimaryLocation()
+	if loc.IsZero() {
+		return fmt.Sprintf("%s: %s", e.Severity, message)
+	}
+
+	file := AbsPath(root, loc.File)
+	if !absolute {
+		file = relativePath(root, file)
+	}
+	loc.File = filepath.ToSlash(file)
+
+	if loc.Line == 0 {
+		loc.Line = 1
+	}
+	return fmt.Sprintf("%s: %s: %s", loc, e.Severity, message)
+}
+
+// relativePath returns path relative to root if it is inside root, or
+// unchanged otherwise
+func relativePath(root, path string) string {
+	base := AbsPath(root, ".")
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,72 @@
+package errclean
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestQuickfixLine(t *testing.T) {
+	root := filepath.FromSlash("/home/dev/app")
+
+	tests := []struct {
+		name     string
+		err      *CleanedError
+		absolute bool
+		expected string
+	}{
+		{
+			name: "Relative location",
+			err: &CleanedError{
+				Type:     "E0382",
+				Message:  "borrow of moved value: `s`",
+				Location: Location{File: "src/main.rs", Line: 5, Column: 20},
+			},
+			expected: "src/main.rs:5:20: error: E0382: borrow of moved value: `s`",
+		},
+		{
+			name: "Absolute location inside the root",
+			err: &CleanedError{
+				Type:     "unused_variables",
+				Message:  "unused variable: `x`",
+				Severity: SeverityWarning,
+				Location: Location{File: "/home/dev/app/src/lib.rs", Line: 10, Column: 9},
+			},
+			expected: "src/lib.rs:10:9: warning: unused_variables: unused variable: `x`",
+		},
+		{
+			name: "Absolute paths",
+			err: &CleanedError{
+				Type:     "build error",
+				Message:  "undefined: fmt.Printl",
+				Location: Location{File: "./main.go", Line: 15, Column: 2},
+			},
+			absolute: true,
+			expected: filepath.ToSlash(filepath.Join(root, "main.go")) + ":15:2: error: build error: undefined: fmt.Printl",
+		},
+		{
+			name: "Location from user frame",
+			err: &CleanedError{
+				Type:    "AttributeError",
+				Message: "'NoneType' object\nhas no attribute 'strip'",
+				Stack: []Frame{
+					{Text: "site-packages", Location: Location{File: "/usr/lib/python3/site-packages/x.py", Line: 1}, Kind: FrameDependency},
+					{Text: "main.py", Location: Location{File: "main.py", Line: 42}},
+				},
+			},
+			expected: "main.py:42: error: AttributeError: 'NoneType' object has no attribute 'strip'",
+		},
+		{
+			name:     "No location",
+			err:      &CleanedError{Type: "npm ENOENT", Message: "no such file or directory"},
+			expected: "error: npm ENOENT: no such file or directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.QuickfixLine(root, tt.absolute); got != tt.expected {
+				t.Errorf("QuickfixLine() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package errclean
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Severity ranks how serious a diagnostic is. The zero value is
+// SeverityError, so parsers that don't set it report errors.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityNote
+	SeverityHelp
+)
+
+var severityNames = []string{"error", "warning", "note", "help"}
+
+// String returns the lowercase severity name
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return "unknown"
+	}
+	return severityNames[s]
+}
+
+// AtLeast reports whether s is at least as severe as min
+func (s Severity) AtLeast(min Severity) bool {
+	return s <= min
+}
+
+// ParseSeverity converts a name such as "warning" to a Severity
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if n == name {
+			return Severity(i), nil
+		}
+	}
+	return SeverityError, fmt.Errorf("unknown severity %q (want error, warning, note or help)", name)
+}
+
+// FilterSeverity returns the diagnostics at least as severe as min
+func FilterSeverity(errs []*CleanedError, min Severity) []*CleanedError {
+	var result []*CleanedError
+	for _, e := range errs {
+		if e.Severity.AtLeast(min) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// SortBySeverity orders diagnostics most severe first, keeping the
+// original order within each severity
+func SortBySeverity(errs []*CleanedError) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Severity < errs[j].Severity
+	})
+}
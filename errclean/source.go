@@ -0,0 +1,102 @@
+package errclean
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Longest source line shown in a snippet; minified code is cut off
+const maxSnippetWidth = 200
+
+// SourceLine is a line of source code shown under a diagnostic
+type SourceLine struct {
+	Number int
+	Text   string
+}
+
+// Snippet is the source code around a location
+type Snippet struct {
+	Location Location
+	Lines    []SourceLine
+}
+
+// ReadSnippet reads the line at loc and context lines on either side of it.
+// Relative paths are resolved against root. It returns nil if the file or
+// line does not exist.
+func ReadSnippet(root string, loc Location, context int) *Snippet {
+	if loc.IsZero() || loc.Line <= 0 {
+		return nil
+	}
+
+	f, err := os.Open(AbsPath(root, loc.File))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	if context < 0 {
+		context = 0
+	}
+	first, last := loc.Line-context, loc.Line+context
+	if first < 1 {
+		first = 1
+	}
+
+	snippet := &Snippet{Location: loc}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; n <= last && scanner.Scan(); n++ {
+		if n >= first {
+			snippet.Lines = append(snippet.Lines, SourceLine{Number: n, Text: scanner.Text()})
+		}
+	}
+
+	// The file is shorter than the reported line
+	if len(snippet.Lines) == 0 || snippet.Lines[len(snippet.Lines)-1].Number < loc.Line {
+		return nil
+	}
+	return snippet
+}
+
+// Format renders the snippet with line numbers, marking the offending line
+// and its column with a caret
+func (s *Snippet) Format() []string {
+	width := len(fmt.Sprint(s.Lines[len(s.Lines)-1].Number))
+
+	var result []string
+	for _, line := range s.Lines {
+		text := strings.TrimRight(line.Text, " \t\r")
+		runes := []rune(text)
+		if len(runes) > maxSnippetWidth {
+			text = string(runes[:maxSnippetWidth]) + "…"
+		}
+
+		marker := " "
+		if line.Number == s.Location.Line {
+			marker = ">"
+		}
+		result = append(result, fmt.Sprintf("%s %*d | %s", marker, width, line.Number, text))
+
+		if line.Number == s.Location.Line && s.Location.Column > 0 && s.Location.Column <= maxSnippetWidth {
+			result = append(result, fmt.Sprintf("  %*s | %s^", width, "", caretIndent(runes, s.Location.Column)))
+		}
+	}
+
+	return result
+}
+
+// caretIndent returns the whitespace that puts a caret under a 1-based
+// column, keeping tabs so the caret lines up with the source
+func caretIndent(line []rune, column int) string {
+	var sb strings.Builder
+	for i := 0; i < column-1; i++ {
+		if i < len(line) && line[i] == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	return sb.String()
+}
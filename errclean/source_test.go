@@ -0,0 +1,63 @@
+package errclean
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadSnippet(t *testing.T) {
+	root := t.TempDir()
+	source := "def main():\n    data = None\n\treturn data.strip()\n\nmain()\n"
+	if err := os.WriteFile(filepath.Join(root, "main.py"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		loc      Location
+		context  int
+		expected []string
+	}{
+		{
+			name:    "Context and caret",
+			loc:     Location{File: "main.py", Line: 3, Column: 13},
+			context: 1,
+			expected: []string{
+				"  2 |     data = None",
+				"> 3 | \treturn data.strip()",
+				"    | \t           ^",
+				"  4 | ",
+			},
+		},
+		{
+			name:     "No column",
+			loc:      Location{File: "main.py", Line: 1},
+			context:  0,
+			expected: []string{"> 1 | def main():"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snippet := ReadSnippet(root, tt.loc, tt.context)
+			if snippet == nil {
+				t.Fatal("ReadSnippet() = nil")
+			}
+
+			got := strings.Join(snippet.Format(), "\n")
+			if want := strings.Join(tt.expected, "\n"); got != want {
+				t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+
+	if ReadSnippet(root, Location{File: "main.py", Line: 99}, 1) != nil {
+		t.Error("ReadSnippet() past the end of the file should be nil")
+	}
+
+	if ReadSnippet(root, Location{File: "missing.py", Line: 1}, 1) != nil {
+		t.Error("ReadSnippet() for a missing file should be nil")
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/XD637/err/explain"
+)
+
+// runExplain implements `err explain <code>`
+func runExplain(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: err explain <code or message>")
+		return 1
+	}
+
+	query := strings.Join(args, " ")
+	entry := explain.Lookup(query)
+	if entry == nil {
+		fmt.Fprintf(os.Stderr, "error: no explanation for %q\n", query)
+		return 1
+	}
+
+	for _, line := range entry.Format() {
+		fmt.Println(line)
+	}
+	return 0
+}
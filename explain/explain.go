@@ -0,0 +1,99 @@
+// Package explain is an offline knowledge base of common error codes and
+// messages, with a short explanation and the usual fixes for each.
+package explain
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Entry explains one error code or message
+type Entry struct {
+	Code     string   // e.g. "E0382", "TS2322", "ENOENT", "KeyError", "nil map"
+	Title    string   // One-line description
+	Summary  string   // What the error means
+	Fixes    []string // Common fixes, most likely first
+	Language string   // Name of the parser whose errors this explains, e.g. "go"
+
+	// Pattern matches the error message, for errors without a code such as
+	// Go panics. Entries with a pattern are checked before codes of the same
+	// language, so they can refine a generic type like TypeError.
+	Pattern *regexp.Regexp
+}
+
+var (
+	byCode   = make(map[string]*Entry)
+	patterns []*Entry
+)
+
+// register adds the entries of a language to the knowledge base
+func register(language string, entries []Entry) {
+	for i := range entries {
+		entry := &entries[i]
+		entry.Language = language
+		byCode[normalize(entry.Code)] = entry
+		if entry.Pattern != nil {
+			patterns = append(patterns, entry)
+		}
+	}
+}
+
+// normalize makes codes case-insensitive and drops the "npm " prefix the
+// npm parser puts in front of its codes
+func normalize(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.TrimPrefix(code, "npm ")
+}
+
+// Lookup finds an entry by code, or by matching the text against message
+// patterns. It returns nil if nothing matches.
+func Lookup(text string) *Entry {
+	if entry, ok := byCode[normalize(text)]; ok {
+		return entry
+	}
+	return matchMessage(text, "")
+}
+
+// ForError finds the entry that explains a cleaned error, or nil. Only
+// entries of the error's language apply: Python's "list index out of
+// range" is an IndexError, not Go's slice index panic. Without a
+// language, codes are tried before message patterns.
+func ForError(e *errclean.CleanedError) *Entry {
+	if e.Language != "" {
+		if entry := matchMessage(e.Message, e.Language); entry != nil {
+			return entry
+		}
+	}
+	if entry, ok := byCode[normalize(e.Type)]; ok && (e.Language == "" || entry.Language == e.Language) {
+		return entry
+	}
+	if e.Language == "" {
+		return matchMessage(e.Message, "")
+	}
+	return nil
+}
+
+// matchMessage returns the first entry of the language whose pattern
+// matches the message. An empty language matches entries of any language.
+func matchMessage(message, language string) *Entry {
+	for _, entry := range patterns {
+		if (language == "" || entry.Language == language) && entry.Pattern.MatchString(message) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Format returns the explanation as lines of text
+func (e *Entry) Format() []string {
+	lines := []string{e.Code + ": " + e.Title, e.Summary}
+	if len(e.Fixes) > 0 {
+		lines = append(lines, "Try:")
+		for _, fix := range e.Fixes {
+			lines = append(lines, "  - "+fix)
+		}
+	}
+	return lines
+}
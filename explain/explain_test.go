@@ -0,0 +1,99 @@
+package explain
+
+import (
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		text string
+		code string // Empty if nothing should match
+	}{
+		{"E0382", "E0382"},
+		{"e0382", "E0382"},
+		{"TS2322", "TS2322"},
+		{"ERESOLVE", "ERESOLVE"},
+		{"npm ENOENT", "ENOENT"},
+		{"KeyError", "KeyError"},
+		{"nil map", "nil map"},
+		{"assignment to entry in nil map", "nil map"},
+		{"E9999", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			entry := Lookup(tt.text)
+			if tt.code == "" {
+				if entry != nil {
+					t.Errorf("Lookup(%q) = %s, want nil", tt.text, entry.Code)
+				}
+				return
+			}
+			if entry == nil || entry.Code != tt.code {
+				t.Errorf("Lookup(%q) = %v, want %s", tt.text, entry, tt.code)
+			}
+		})
+	}
+}
+
+func TestForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  errclean.CleanedError
+		code string
+	}{
+		{
+			name: "rust code",
+			err:  errclean.CleanedError{Language: "rust", Type: "E0382", Message: "borrow of moved value: `s`"},
+			code: "E0382",
+		},
+		{
+			name: "npm code",
+			err:  errclean.CleanedError{Language: "javascript", Type: "npm ERESOLVE", Message: "unable to resolve dependency tree"},
+			code: "ERESOLVE",
+		},
+		{
+			name: "go panic by message",
+			err:  errclean.CleanedError{Language: "go", Type: "panic", Message: "runtime error: index out of range [5] with length 3"},
+			code: "index out of range",
+		},
+		{
+			name: "javascript message refines TypeError",
+			err:  errclean.CleanedError{Language: "javascript", Type: "TypeError", Message: "Cannot read properties of undefined (reading 'map')"},
+			code: "undefined property",
+		},
+		{
+			name: "python TypeError",
+			err:  errclean.CleanedError{Language: "python", Type: "TypeError", Message: "unsupported operand type(s) for +: 'int' and 'str'"},
+			code: "TypeError",
+		},
+		{
+			name: "python message that reads like a go panic",
+			err:  errclean.CleanedError{Language: "python", Type: "IndexError", Message: "list index out of range"},
+			code: "IndexError",
+		},
+		{
+			name: "code without a language",
+			err:  errclean.CleanedError{Type: "IndexError", Message: "list index out of range"},
+			code: "IndexError",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := ForError(&tt.err)
+			if entry == nil || entry.Code != tt.code {
+				t.Errorf("ForError() = %v, want %s", entry, tt.code)
+			}
+		})
+	}
+
+	if entry := ForError(&errclean.CleanedError{Language: "go", Type: "panic", Message: "something custom"}); entry != nil {
+		t.Errorf("ForError() = %s for an unknown panic, want nil", entry.Code)
+	}
+	if entry := ForError(&errclean.CleanedError{Language: "elixir", Type: "KeyError", Message: "key :id not found"}); entry != nil {
+		t.Errorf("ForError() = %s for another language's KeyError, want nil", entry.Code)
+	}
+}
@@ -0,0 +1,97 @@
+package explain
+
+import "regexp"
+
+func init() {
+	register("go", goEntries)
+}
+
+// goEntries covers Go runtime panics and fatal errors, matched by message
+var goEntries = []Entry{
+	{
+		Code:    "nil map",
+		Title:   "assignment to entry in nil map",
+		Summary: "The map was declared but never initialized; reading a nil map works, writing panics.",
+		Fixes: []string{
+			"Initialize it with `make(map[K]V)` or a literal",
+			"Initialize map fields in the struct's constructor",
+		},
+		Pattern: regexp.MustCompile(`assignment to entry in nil map`),
+	},
+	{
+		Code:    "nil pointer",
+		Title:   "nil pointer dereference",
+		Summary: "A nil pointer, interface or map value was dereferenced.",
+		Fixes: []string{
+			"Check the error returned alongside the value",
+			"Check for nil before use",
+		},
+		Pattern: regexp.MustCompile(`invalid memory address or nil pointer dereference`),
+	},
+	{
+		Code:    "index out of range",
+		Title:   "index out of range",
+		Summary: "A slice, array or string is indexed past its length.",
+		Fixes: []string{
+			"Check `len()` before indexing",
+			"Look for off-by-one errors in loops",
+		},
+		Pattern: regexp.MustCompile(`index out of range`),
+	},
+	{
+		Code:    "slice bounds",
+		Title:   "slice bounds out of range",
+		Summary: "A slice expression uses bounds past the capacity or with low > high.",
+		Fixes:   []string{"Clamp the bounds to the length before slicing"},
+		Pattern: regexp.MustCompile(`slice bounds out of range`),
+	},
+	{
+		Code:    "concurrent map writes",
+		Title:   "concurrent map access",
+		Summary: "Maps are not safe for concurrent use; the runtime detected simultaneous writes.",
+		Fixes: []string{
+			"Protect the map with a `sync.Mutex` or `sync.RWMutex`",
+			"Use `sync.Map` for caches with disjoint keys",
+		},
+		Pattern: regexp.MustCompile(`concurrent map (?:writes|read and map write|iteration and map write)`),
+	},
+	{
+		Code:    "deadlock",
+		Title:   "all goroutines are asleep",
+		Summary: "Every goroutine is blocked, usually on a channel nobody sends to or receives from.",
+		Fixes: []string{
+			"Check that every receive has a matching send, and vice versa",
+			"Close channels when producers finish",
+			"Make sure `wg.Done()` runs for every `wg.Add`",
+		},
+		Pattern: regexp.MustCompile(`all goroutines are asleep - deadlock`),
+	},
+	{
+		Code:    "closed channel",
+		Title:   "send on closed channel",
+		Summary: "A value was sent on a channel after it was closed.",
+		Fixes:   []string{"Only the sender should close a channel, after its last send"},
+		Pattern: regexp.MustCompile(`send on closed channel|close of closed channel`),
+	},
+	{
+		Code:    "type assertion",
+		Title:   "interface conversion failed",
+		Summary: "A type assertion `x.(T)` failed because the dynamic type is not T.",
+		Fixes:   []string{"Use the two-value form `v, ok := x.(T)`"},
+		Pattern: regexp.MustCompile(`interface conversion:`),
+	},
+	{
+		Code:    "divide by zero",
+		Title:   "integer divide by zero",
+		Summary: "An integer was divided by zero.",
+		Fixes:   []string{"Check the divisor before dividing"},
+		Pattern: regexp.MustCompile(`integer divide by zero`),
+	},
+	{
+		Code:    "stack overflow",
+		Title:   "goroutine stack exceeds limit",
+		Summary: "Unbounded recursion, often a method calling itself, e.g. String() formatting its own receiver.",
+		Fixes:   []string{"Check the recursion's base case"},
+		Pattern: regexp.MustCompile(`stack overflow|goroutine stack exceeds`),
+	},
+}
@@ -0,0 +1,96 @@
+package explain
+
+func init() {
+	register("javascript", npmEntries)
+}
+
+// npmEntries covers npm error codes, which are also Node system errors
+var npmEntries = []Entry{
+	{
+		Code:    "ENOENT",
+		Title:   "no such file or directory",
+		Summary: "A file or directory the command needs doesn't exist, often package.json in the wrong directory.",
+		Fixes: []string{
+			"Run the command from the project root",
+			"Check the path in the message",
+		},
+	},
+	{
+		Code:    "ELIFECYCLE",
+		Title:   "a package script failed",
+		Summary: "An npm script exited with a non-zero status. The real error is in the script output above.",
+		Fixes: []string{
+			"Scroll up to the first error from the script itself",
+			"Run the script directly to see its full output",
+		},
+	},
+	{
+		Code:    "ERESOLVE",
+		Title:   "unable to resolve dependency tree",
+		Summary: "Two packages require incompatible versions of a peer dependency.",
+		Fixes: []string{
+			"Upgrade the packages so their peer ranges overlap",
+			"Retry with `npm install --legacy-peer-deps`",
+		},
+	},
+	{
+		Code:    "EACCES",
+		Title:   "permission denied",
+		Summary: "npm can't write to a directory, usually the global install prefix.",
+		Fixes: []string{
+			"Don't use sudo; set a user-owned prefix with `npm config set prefix ~/.npm-global`",
+			"Use a Node version manager such as nvm",
+		},
+	},
+	{
+		Code:    "EADDRINUSE",
+		Title:   "address already in use",
+		Summary: "Another process is already listening on the port.",
+		Fixes: []string{
+			"Stop the other process (`lsof -i :<port>`)",
+			"Use a different port",
+		},
+	},
+	{
+		Code:    "ECONNREFUSED",
+		Title:   "connection refused",
+		Summary: "Nothing is listening at the host and port being connected to.",
+		Fixes: []string{
+			"Start the server or database",
+			"Check the host and port in the configuration",
+		},
+	},
+	{
+		Code:    "ETIMEDOUT",
+		Title:   "connection timed out",
+		Summary: "The remote host didn't answer in time, often a proxy or network issue.",
+		Fixes: []string{
+			"Check network and proxy settings (`npm config get proxy`)",
+			"Retry; registries sometimes time out",
+		},
+	},
+	{
+		Code:    "E404",
+		Title:   "package not found",
+		Summary: "The registry has no package with this name, or it is private.",
+		Fixes: []string{
+			"Check the package name for typos",
+			"Log in to the registry for scoped or private packages",
+		},
+	},
+	{
+		Code:    "E401",
+		Title:   "unauthorized",
+		Summary: "The registry rejected the credentials.",
+		Fixes:   []string{"Run `npm login`, or update the token in .npmrc"},
+	},
+	{
+		Code:    "EINTEGRITY",
+		Title:   "checksum mismatch",
+		Summary: "A downloaded package doesn't match the hash in the lockfile.",
+		Fixes: []string{
+			"Run `npm cache verify`",
+			"Delete node_modules and package-lock.json and reinstall",
+		},
+	},
+}
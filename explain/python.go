@@ -0,0 +1,127 @@
+package explain
+
+func init() {
+	register("python", pythonEntries)
+}
+
+// pythonEntries covers Python's built-in exceptions
+var pythonEntries = []Entry{
+	{
+		Code:    "ModuleNotFoundError",
+		Title:   "module not found",
+		Summary: "The import refers to a package that isn't installed in the active environment.",
+		Fixes: []string{
+			"Install it: `pip install <package>`",
+			"Activate the right virtualenv",
+			"Check that the package name matches the import name",
+		},
+	},
+	{
+		Code:    "ImportError",
+		Title:   "import failed",
+		Summary: "The module was found, but a name in it couldn't be imported, often due to a circular import.",
+		Fixes: []string{
+			"Check that the name exists in that version of the package",
+			"Break circular imports by moving the import into a function",
+		},
+	},
+	{
+		Code:    "AttributeError",
+		Title:   "attribute not found",
+		Summary: "The object has no attribute with that name, often because it is None.",
+		Fixes: []string{
+			"Check for None before the access",
+			"Check the spelling and the object's type",
+		},
+	},
+	{
+		Code:    "KeyError",
+		Title:   "missing dictionary key",
+		Summary: "The key is not in the dictionary.",
+		Fixes: []string{
+			"Use `d.get(key, default)`",
+			"Check `if key in d` first",
+		},
+	},
+	{
+		Code:    "IndexError",
+		Title:   "index out of range",
+		Summary: "A sequence is indexed past its end.",
+		Fixes: []string{
+			"Check `len()` before indexing",
+			"Look for off-by-one errors in loops",
+		},
+	},
+	{
+		Code:    "TypeError",
+		Title:   "operation on the wrong type",
+		Summary: "An operation or call received a value of an unsupported type, such as None or the wrong number of arguments.",
+		Fixes: []string{
+			"Check the types of the operands in the message",
+			"Convert explicitly, e.g. `str(n)` or `int(s)`",
+		},
+	},
+	{
+		Code:    "ValueError",
+		Title:   "invalid value",
+		Summary: "The value has the right type but an invalid content, e.g. `int('abc')`.",
+		Fixes:   []string{"Validate or sanitize the input before converting"},
+	},
+	{
+		Code:    "NameError",
+		Title:   "name is not defined",
+		Summary: "The variable or function is not defined in the current scope.",
+		Fixes: []string{
+			"Check the spelling",
+			"Define or import it before use",
+		},
+	},
+	{
+		Code:    "UnboundLocalError",
+		Title:   "local variable referenced before assignment",
+		Summary: "A variable assigned somewhere in the function is read before that assignment.",
+		Fixes: []string{
+			"Initialize it at the top of the function",
+			"Use `global` or `nonlocal` if you meant the outer variable",
+		},
+	},
+	{
+		Code:    "ZeroDivisionError",
+		Title:   "division by zero",
+		Summary: "The divisor is zero.",
+		Fixes:   []string{"Check the divisor before dividing"},
+	},
+	{
+		Code:    "FileNotFoundError",
+		Title:   "file not found",
+		Summary: "The path doesn't exist, often because it is relative to a different working directory.",
+		Fixes: []string{
+			"Build paths from `__file__` or `pathlib.Path(__file__).parent`",
+			"Check the current working directory",
+		},
+	},
+	{
+		Code:    "RecursionError",
+		Title:   "maximum recursion depth exceeded",
+		Summary: "A function recursed too deeply, usually because the base case is never reached.",
+		Fixes:   []string{"Check the recursion's base case, or rewrite it as a loop"},
+	},
+	{
+		Code:    "IndentationError",
+		Title:   "unexpected indentation",
+		Summary: "The indentation doesn't match the block structure.",
+		Fixes:   []string{"Don't mix tabs and spaces; re-indent the block"},
+	},
+	{
+		Code:    "SyntaxError",
+		Title:   "invalid syntax",
+		Summary: "The code can't be parsed.",
+		Fixes:   []string{"Look for an unclosed bracket or quote just before the location"},
+	},
+	{
+		Code:    "StopIteration",
+		Title:   "iterator exhausted",
+		Summary: "`next()` was called on an iterator with no items left.",
+		Fixes:   []string{"Pass a default: `next(it, None)`"},
+	},
+}
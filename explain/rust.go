@@ -0,0 +1,142 @@
+package explain
+
+func init() {
+	register("rust", rustEntries)
+}
+
+// rustEntries covers the rustc error codes people hit most often
+var rustEntries = []Entry{
+	{
+		Code:    "E0382",
+		Title:   "use of moved value",
+		Summary: "The value was moved into another variable or function, so the original binding can no longer be used.",
+		Fixes: []string{
+			"Borrow instead of moving: pass `&value` or `&mut value`",
+			"Call `.clone()` if you need an independent copy",
+			"Derive or implement `Copy` for small plain-data types",
+		},
+	},
+	{
+		Code:    "E0499",
+		Title:   "more than one mutable borrow at a time",
+		Summary: "Two `&mut` references to the same value are alive at once.",
+		Fixes: []string{
+			"Limit the first borrow's scope so it ends before the second starts",
+			"Split the data, e.g. with `split_at_mut` or separate struct fields",
+			"Use `RefCell` or `Mutex` for shared mutation",
+		},
+	},
+	{
+		Code:    "E0502",
+		Title:   "cannot borrow as mutable because it is also borrowed as immutable",
+		Summary: "A shared reference is still in use while the code tries to mutate the same value.",
+		Fixes: []string{
+			"Finish using the shared reference before mutating",
+			"Copy or clone the data you need out of the shared borrow first",
+		},
+	},
+	{
+		Code:    "E0505",
+		Title:   "cannot move out of value because it is borrowed",
+		Summary: "The value is moved while a reference to it is still alive.",
+		Fixes: []string{
+			"End the borrow before the move",
+			"Clone the value, or move a clone instead",
+		},
+	},
+	{
+		Code:    "E0106",
+		Title:   "missing lifetime specifier",
+		Summary: "A reference in a struct or return type needs a lifetime the compiler can't infer.",
+		Fixes: []string{
+			"Add a lifetime parameter: `struct Foo<'a> { s: &'a str }`",
+			"Return an owned type such as `String` instead of `&str`",
+		},
+	},
+	{
+		Code:    "E0308",
+		Title:   "mismatched types",
+		Summary: "An expression has a different type than the one expected at that position.",
+		Fixes: []string{
+			"Check the expected and found types in the note",
+			"Convert explicitly, e.g. `.into()`, `as`, `.to_string()` or `&`",
+			"Remove a trailing `;` if a block should return a value",
+		},
+	},
+	{
+		Code:    "E0425",
+		Title:   "cannot find value in this scope",
+		Summary: "The name is not defined, not imported, or misspelled.",
+		Fixes: []string{
+			"Check the spelling",
+			"Import it with `use`",
+			"Declare the variable before using it",
+		},
+	},
+	{
+		Code:    "E0433",
+		Title:   "failed to resolve: use of undeclared crate or module",
+		Summary: "A path refers to a crate or module that isn't in scope.",
+		Fixes: []string{
+			"Add the crate to Cargo.toml",
+			"Add a `use` statement or `mod` declaration",
+		},
+	},
+	{
+		Code:    "E0599",
+		Title:   "no method found",
+		Summary: "The type has no method with that name, or the trait providing it is not in scope.",
+		Fixes: []string{
+			"Import the trait that provides the method",
+			"Check that the receiver has the type you expect",
+		},
+	},
+	{
+		Code:    "E0277",
+		Title:   "trait bound not satisfied",
+		Summary: "A type is used where it must implement a trait it doesn't implement.",
+		Fixes: []string{
+			"Derive or implement the trait, e.g. `#[derive(Debug, Clone)]`",
+			"Add the bound to your generic parameter: `T: Display`",
+		},
+	},
+	{
+		Code:    "E0384",
+		Title:   "cannot assign twice to immutable variable",
+		Summary: "Variables are immutable by default.",
+		Fixes:   []string{"Declare it with `let mut`"},
+	},
+	{
+		Code:    "E0596",
+		Title:   "cannot borrow as mutable",
+		Summary: "A mutable borrow is taken of a binding or reference that isn't mutable.",
+		Fixes: []string{
+			"Declare the binding with `let mut`",
+			"Take `&mut self` or `&mut T` instead of `&self` or `&T`",
+		},
+	},
+	{
+		Code:    "E0597",
+		Title:   "borrowed value does not live long enough",
+		Summary: "A reference outlives the value it points to.",
+		Fixes: []string{
+			"Move the value to an outer scope",
+			"Return or store an owned value instead of a reference",
+		},
+	},
+	{
+		Code:    "E0432",
+		Title:   "unresolved import",
+		Summary: "A `use` path doesn't point to an existing item.",
+		Fixes: []string{
+			"Check the path and spelling",
+			"Enable the crate feature that exports the item",
+		},
+	},
+	{
+		Code:    "E0061",
+		Title:   "wrong number of function arguments",
+		Summary: "The call passes a different number of arguments than the function takes.",
+		Fixes:   []string{"Match the call to the function signature"},
+	},
+}
@@ -0,0 +1,165 @@
+package explain
+
+import "regexp"
+
+// tsc diagnostics are reported by the javascript parser
+func init() {
+	register("javascript", typescriptEntries)
+	register("javascript", javascriptEntries)
+}
+
+// typescriptEntries covers the most common tsc diagnostics
+var typescriptEntries = []Entry{
+	{
+		Code:    "TS2322",
+		Title:   "type is not assignable",
+		Summary: "A value of one type is assigned where an incompatible type is expected.",
+		Fixes: []string{
+			"Read the chained message for the property that differs",
+			"Fix the value, or widen the declared type",
+			"Narrow with a type guard before assigning",
+		},
+	},
+	{
+		Code:    "TS2345",
+		Title:   "argument type is not assignable to parameter",
+		Summary: "A function is called with an argument of the wrong type.",
+		Fixes: []string{
+			"Convert or narrow the argument",
+			"Check for `undefined` or `null` before the call",
+		},
+	},
+	{
+		Code:    "TS2304",
+		Title:   "cannot find name",
+		Summary: "The identifier is not declared or not imported.",
+		Fixes: []string{
+			"Import it, or check the spelling",
+			"Install type definitions, e.g. `npm i -D @types/node`",
+		},
+	},
+	{
+		Code:    "TS2307",
+		Title:   "cannot find module",
+		Summary: "The import path doesn't resolve to a file or package with types.",
+		Fixes: []string{
+			"Install the package, or fix the relative path",
+			"Install its types: `npm i -D @types/<package>`",
+			"Check `paths` and `moduleResolution` in tsconfig.json",
+		},
+	},
+	{
+		Code:    "TS2339",
+		Title:   "property does not exist on type",
+		Summary: "The property is not declared on the type the compiler sees.",
+		Fixes: []string{
+			"Add the property to the interface or type",
+			"Narrow a union type before accessing the property",
+		},
+	},
+	{
+		Code:    "TS2531",
+		Title:   "object is possibly null",
+		Summary: "With strictNullChecks, a value that may be null is used without a check.",
+		Fixes: []string{
+			"Check for null first, or use optional chaining `?.`",
+			"Use `!` only if you know the value is set",
+		},
+	},
+	{
+		Code:    "TS2532",
+		Title:   "object is possibly undefined",
+		Summary: "With strictNullChecks, a value that may be undefined is used without a check.",
+		Fixes: []string{
+			"Check for undefined first, or use optional chaining `?.`",
+			"Provide a default with `??`",
+		},
+	},
+	{
+		Code:    "TS18048",
+		Title:   "value is possibly undefined",
+		Summary: "With strictNullChecks, a value that may be undefined is used without a check.",
+		Fixes: []string{
+			"Check for undefined first, or use optional chaining `?.`",
+			"Provide a default with `??`",
+		},
+	},
+	{
+		Code:    "TS2554",
+		Title:   "wrong number of arguments",
+		Summary: "The call passes a different number of arguments than the function expects.",
+		Fixes:   []string{"Match the call to the function signature, or make parameters optional"},
+	},
+	{
+		Code:    "TS2741",
+		Title:   "property is missing in type",
+		Summary: "An object literal or value lacks a required property.",
+		Fixes: []string{
+			"Add the missing property",
+			"Mark it optional in the type with `?`",
+		},
+	},
+	{
+		Code:    "TS7006",
+		Title:   "parameter implicitly has an 'any' type",
+		Summary: "With noImplicitAny, every parameter needs a type the compiler can infer or read.",
+		Fixes:   []string{"Annotate the parameter type"},
+	},
+	{
+		Code:    "TS7016",
+		Title:   "could not find a declaration file for module",
+		Summary: "The package ships JavaScript without type definitions.",
+		Fixes: []string{
+			"Install its types: `npm i -D @types/<package>`",
+			"Declare the module yourself: `declare module '<package>';`",
+		},
+	},
+	{
+		Code:    "TS1005",
+		Title:   "syntax error: token expected",
+		Summary: "The parser expected a token such as `;`, `)` or `}` at this position.",
+		Fixes:   []string{"Look for an unclosed bracket or string just before the location"},
+	},
+	{
+		Code:    "TS2365",
+		Title:   "operator cannot be applied to types",
+		Summary: "An operator such as `+` or `<` is used with operand types it doesn't support.",
+		Fixes:   []string{"Convert the operands to a common type first"},
+	},
+}
+
+// javascriptEntries refine generic runtime errors by their message
+var javascriptEntries = []Entry{
+	{
+		Code:    "undefined property",
+		Title:   "cannot read properties of undefined or null",
+		Summary: "A property is accessed on a value that is undefined or null, often data that hasn't loaded or a missing return.",
+		Fixes: []string{
+			"Find where the value should have been set",
+			"Use optional chaining `obj?.prop` or a default `obj ?? {}`",
+			"Await the promise that produces the value",
+		},
+		Pattern: regexp.MustCompile(`Cannot read propert(?:y|ies) .*of (?:undefined|null)|(?:undefined|null) is not an object`),
+	},
+	{
+		Code:    "not a function",
+		Title:   "value is not a function",
+		Summary: "The code calls something that isn't a function, usually a misspelled method or a wrong import.",
+		Fixes: []string{
+			"Check the spelling of the method",
+			"Check default vs named imports",
+			"Log the value to see what it actually is",
+		},
+		Pattern: regexp.MustCompile(`is not a function`),
+	},
+	{
+		Code:    "not defined",
+		Title:   "variable is not defined",
+		Summary: "The name is not declared in any enclosing scope.",
+		Fixes: []string{
+			"Declare or import it",
+			"Check the spelling",
+		},
+		Pattern: regexp.MustCompile(`^\S+ is not defined$`),
+	},
+}
@@ -0,0 +1,187 @@
+package hints
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// pipPackages maps import names to the pip package that provides them,
+// where the two differ
+var pipPackages = map[string]string{
+	"cv2":      "opencv-python",
+	"yaml":     "PyYAML",
+	"PIL":      "Pillow",
+	"sklearn":  "scikit-learn",
+	"bs4":      "beautifulsoup4",
+	"dotenv":   "python-dotenv",
+	"jwt":      "PyJWT",
+	"dateutil": "python-dateutil",
+	"attr":     "attrs",
+	"serial":   "pyserial",
+	"magic":    "python-magic",
+	"Crypto":   "pycryptodome",
+}
+
+// builtinRules cover the mistakes we see most often
+var builtinRules = []Rule{
+	// Python
+	{
+		Type:     "ModuleNotFoundError",
+		Message:  `No module named '([\w.]+)'`,
+		Language: "python",
+		suggest: func(_ *Engine, e *errclean.CleanedError, groups []string) string {
+			module := strings.Split(groups[1], ".")[0]
+			pkg, ok := pipPackages[module]
+			if !ok {
+				pkg = module
+			}
+			return fmt.Sprintf("pip install %s (or activate the virtualenv it is installed in)", pkg)
+		},
+	},
+	{
+		Type:     "NameError",
+		Message:  `name '(\w+)' is not defined`,
+		Language: "python",
+		suggest:  identifierRule,
+	},
+	{
+		Type:     "KeyError",
+		Language: "python",
+		Hint:     "Use dict.get(key, default) or check `key in d` before indexing",
+	},
+	{
+		Type:     "AttributeError",
+		Message:  `'NoneType' object has no attribute '(\w+)'`,
+		Language: "python",
+		Hint:     "The object is None; find where it should have been set before .$1 is accessed",
+	},
+
+	// JavaScript and TypeScript
+	{
+		Type:     "npm ERESOLVE",
+		Language: "javascript",
+		Hint:     "Peer dependency conflict: upgrade the package named after \"Could not resolve dependency\", or retry with npm install --legacy-peer-deps",
+	},
+	{
+		Type:     "npm ENOENT",
+		Message:  `package\.json`,
+		Language: "javascript",
+		Hint:     "Run the command from the directory that contains package.json",
+	},
+	{
+		Type:     "npm ELIFECYCLE",
+		Language: "javascript",
+		Hint:     "A package script failed; the real error is in the script output above this one",
+	},
+	{
+		Type:     "ReferenceError",
+		Message:  `^([\w$]+) is not defined`,
+		Language: "javascript",
+		suggest:  identifierRule,
+	},
+	{
+		Message:  `Cannot find name '(\w+)'`,
+		Language: "javascript",
+		suggest:  identifierRule,
+	},
+	{
+		Message:  `Cannot find module '([^'./][^']*)'`,
+		Language: "javascript",
+		suggest: func(_ *Engine, e *errclean.CleanedError, groups []string) string {
+			return "npm install " + packageName(groups[1])
+		},
+	},
+	{
+		Message:  `Cannot find module '(\.[^']*)'`,
+		Language: "javascript",
+		Hint:     "Check the relative path $1 and the file extension",
+	},
+	{
+		Type:     "TS7016",
+		Message:  `module '([^'./][^']*)'`,
+		Language: "javascript",
+		suggest: func(_ *Engine, e *errclean.CleanedError, groups []string) string {
+			return "npm install -D @types/" + strings.ReplaceAll(strings.TrimPrefix(packageName(groups[1]), "@"), "/", "__")
+		},
+	},
+
+	// Go
+	{
+		Message:  `undefined: (\w+)\.(\w+)`,
+		Language: "go",
+		suggest: func(_ *Engine, e *errclean.CleanedError, groups []string) string {
+			if name := closest(groups[2], goPackageAPI[groups[1]]); name != "" {
+				return fmt.Sprintf("Did you mean %s.%s?", groups[1], name)
+			}
+			return ""
+		},
+	},
+	{
+		Message:  `undefined: (\w+)$`,
+		Language: "go",
+		suggest:  identifierRule,
+	},
+	{
+		Message:  `"([^"]+)" imported and not used`,
+		Language: "go",
+		Hint:     "Remove the import of \"$1\", or run goimports to manage imports",
+	},
+	{
+		Message:  `declared and not used: (\w+)`,
+		Language: "go",
+		Hint:     "Use $1 or remove it; assign it to _ if you only need the side effect",
+	},
+	{
+		Message:  `assignment to entry in nil map`,
+		Language: "go",
+		Hint:     "Initialize the map with make() before writing to it",
+	},
+
+	// Rust
+	{
+		Type:     "E0382",
+		Message:  "moved value: `([^`]+)`",
+		Language: "rust",
+		Hint:     "Borrow with `&$1` instead of moving it, or call `$1.clone()` where it is moved",
+	},
+	{
+		Message:  "cannot find (?:value|function|type|struct|macro|trait) `(\\w+)`",
+		Language: "rust",
+		suggest:  identifierRule,
+	},
+	{
+		Type:     "E0502|E0499",
+		Language: "rust",
+		Hint:     "End the first borrow before the second starts, e.g. by copying what you need out of it",
+	},
+	{
+		Type:     "E0384",
+		Message:  "variable `([^`]+)`",
+		Language: "rust",
+		Hint:     "Declare it as `let mut $1`",
+	},
+	{
+		Type:     "E0433",
+		Message:  "undeclared crate or module `([^`]+)`",
+		Language: "rust",
+		Hint:     "Add it with `cargo add $1`, or import it with `use`",
+	},
+}
+
+// identifierRule suggests project identifiers close to the unknown name in
+// the rule's first group
+func identifierRule(engine *Engine, e *errclean.CleanedError, groups []string) string {
+	return suggestIdentifier(engine, e, groups[1])
+}
+
+// packageName returns the npm package for an import path:
+// "lodash/fp" -> "lodash", "@scope/pkg/sub" -> "@scope/pkg"
+func packageName(path string) string {
+	parts := strings.Split(path, "/")
+	if strings.HasPrefix(path, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
@@ -0,0 +1,98 @@
+package hints
+
+import (
+	"sort"
+	"strings"
+)
+
+// distance returns the Levenshtein edit distance between two strings
+func distance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
+
+// closest returns the candidate nearest to word, or "" if none is close
+// enough to be a plausible typo
+func closest(word string, candidates []string) string {
+	if matches := nearest(word, candidates, 1); len(matches) > 0 {
+		return matches[0]
+	}
+	return ""
+}
+
+// nearest returns up to n candidates that are plausible typos of word,
+// best first. A candidate that differs only in case ranks first; on a tie,
+// the name the typo shares more of its start with wins, so "Printl" is
+// Println rather than Print.
+func nearest(word string, candidates []string, n int) []string {
+	// Allow one edit for short names and one more per 4 characters
+	limit := 1 + len(word)/4
+
+	type match struct {
+		name     string
+		distance int
+		prefix   int
+	}
+	var matches []match
+
+	lower := strings.ToLower(word)
+	for _, candidate := range candidates {
+		if candidate == word {
+			continue
+		}
+		// Skipping by length first avoids most of the distance computations
+		if diff := len(candidate) - len(word); diff > limit || -diff > limit {
+			continue
+		}
+		if d := distance(lower, strings.ToLower(candidate)); d <= limit {
+			matches = append(matches, match{candidate, d, commonPrefix(word, candidate)})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		if matches[i].prefix != matches[j].prefix {
+			return matches[i].prefix > matches[j].prefix
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	if len(matches) > n {
+		matches = matches[:n]
+	}
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m.name
+	}
+	return names
+}
+
+// commonPrefix returns the length of the common prefix of two strings,
+// ignoring case
+func commonPrefix(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
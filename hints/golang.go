@@ -0,0 +1,58 @@
+package hints
+
+// goPackageAPI lists the exported names of commonly used standard library
+// packages, for did-you-mean suggestions on "undefined: pkg.Name"
+var goPackageAPI = map[string][]string{
+	"fmt": {
+		"Errorf", "Fprint", "Fprintf", "Fprintln", "Fscan", "Fscanf", "Fscanln",
+		"Print", "Printf", "Println", "Scan", "Scanf", "Scanln", "Sprint",
+		"Sprintf", "Sprintln", "Sscan", "Sscanf", "Sscanln", "Stringer",
+	},
+	"strings": {
+		"Builder", "Compare", "Contains", "ContainsAny", "ContainsRune", "Count",
+		"Cut", "CutPrefix", "CutSuffix", "EqualFold", "Fields", "FieldsFunc",
+		"HasPrefix", "HasSuffix", "Index", "IndexAny", "IndexByte", "IndexRune",
+		"Join", "LastIndex", "Map", "NewReader", "NewReplacer", "Repeat",
+		"Replace", "ReplaceAll", "Split", "SplitAfter", "SplitN", "Title",
+		"ToLower", "ToTitle", "ToUpper", "Trim", "TrimFunc", "TrimLeft",
+		"TrimPrefix", "TrimRight", "TrimSpace", "TrimSuffix",
+	},
+	"strconv": {
+		"Atoi", "FormatBool", "FormatFloat", "FormatInt", "FormatUint", "Itoa",
+		"ParseBool", "ParseFloat", "ParseInt", "ParseUint", "Quote", "Unquote",
+	},
+	"errors": {"As", "Is", "Join", "New", "Unwrap"},
+	"os": {
+		"Args", "Chdir", "Create", "Environ", "Executable", "Exit", "Getenv",
+		"Getwd", "Hostname", "LookupEnv", "Mkdir", "MkdirAll", "MkdirTemp",
+		"Open", "OpenFile", "ReadDir", "ReadFile", "Remove", "RemoveAll",
+		"Rename", "Setenv", "Stat", "Stderr", "Stdin", "Stdout", "TempDir",
+		"Unsetenv", "UserHomeDir", "WriteFile",
+	},
+	"io": {
+		"Copy", "CopyN", "Discard", "EOF", "MultiReader", "MultiWriter", "NopCloser",
+		"Pipe", "ReadAll", "ReadFull", "Reader", "TeeReader", "WriteString", "Writer",
+	},
+	"time": {
+		"After", "AfterFunc", "Date", "Duration", "Hour", "Millisecond", "Minute",
+		"NewTicker", "NewTimer", "Now", "Parse", "ParseDuration", "Second",
+		"Since", "Sleep", "Tick", "Unix", "Until",
+	},
+	"sort": {"Float64s", "Ints", "Search", "SearchInts", "Slice", "SliceStable", "Sort", "Stable", "Strings"},
+	"filepath": {
+		"Abs", "Base", "Clean", "Dir", "Ext", "FromSlash", "Glob", "IsAbs",
+		"Join", "Match", "Rel", "Split", "ToSlash", "Walk", "WalkDir",
+	},
+	"json": {"Marshal", "MarshalIndent", "NewDecoder", "NewEncoder", "Unmarshal", "Valid"},
+	"http": {
+		"Error", "Get", "Handle", "HandleFunc", "ListenAndServe", "NewRequest",
+		"NewRequestWithContext", "NewServeMux", "NotFound", "Post", "Redirect",
+		"StatusText",
+	},
+	"sync":    {"Map", "Mutex", "Once", "OnceFunc", "OnceValue", "Pool", "RWMutex", "WaitGroup"},
+	"context": {"Background", "TODO", "WithCancel", "WithDeadline", "WithTimeout", "WithValue"},
+	"bytes":   {"Buffer", "Compare", "Contains", "Equal", "HasPrefix", "Index", "Join", "NewBuffer", "NewReader", "Split", "TrimSpace"},
+	"slices":  {"Contains", "Equal", "Index", "Insert", "Max", "Min", "Reverse", "Sort", "SortFunc"},
+	"maps":    {"Clone", "Copy", "DeleteFunc", "Equal", "Keys", "Values"},
+	"log":     {"Fatal", "Fatalf", "Fatalln", "New", "Panic", "Panicf", "Print", "Printf", "Println", "SetFlags", "SetOutput", "SetPrefix"},
+}
@@ -0,0 +1,157 @@
+// Package hints suggests next steps for cleaned errors. Rules match an
+// error's type, message and language; built-in rules cover common
+// mistakes, and users can add their own in a JSON config file.
+package hints
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Rule matches errors and produces a hint for them
+type Rule struct {
+	// Type is a regular expression matched against the whole error type,
+	// e.g. "ModuleNotFoundError" or "npm E.*". Empty matches any type.
+	Type string `json:"type"`
+
+	// Message is a regular expression searched for in the message. Its
+	// groups can be used in Hint as $1 or ${name}. Empty matches any message.
+	Message string `json:"message"`
+
+	// Language is the parser name (javascript, python, go, rust). Empty
+	// matches any language.
+	Language string `json:"language"`
+
+	// Hint is the suggested next step
+	Hint string `json:"hint"`
+
+	typePattern    *regexp.Regexp
+	messagePattern *regexp.Regexp
+
+	// suggest computes the hint for built-in rules that need more than a
+	// template, e.g. a did-you-mean. An empty result means no hint.
+	suggest func(engine *Engine, e *errclean.CleanedError, groups []string) string
+}
+
+// compile prepares the rule's patterns
+func (r *Rule) compile() error {
+	var err error
+	if r.typePattern, err = regexp.Compile("^(?:" + r.Type + ")$"); err != nil {
+		return fmt.Errorf("invalid type pattern %q: %v", r.Type, err)
+	}
+	if r.messagePattern, err = regexp.Compile(r.Message); err != nil {
+		return fmt.Errorf("invalid message pattern %q: %v", r.Message, err)
+	}
+	if r.Hint == "" && r.suggest == nil {
+		return fmt.Errorf("rule for %q has no hint", r.Type+r.Message)
+	}
+	return nil
+}
+
+// apply returns the rule's hint for an error, or "" if it doesn't match
+func (r *Rule) apply(engine *Engine, e *errclean.CleanedError) string {
+	if r.Language != "" && r.Language != e.Language {
+		return ""
+	}
+	if r.Type != "" && !r.typePattern.MatchString(e.Type) {
+		return ""
+	}
+
+	match := r.messagePattern.FindStringSubmatchIndex(e.Message)
+	if match == nil {
+		return ""
+	}
+
+	if r.suggest != nil {
+		groups := make([]string, len(match)/2)
+		for i := range groups {
+			if match[2*i] >= 0 {
+				groups[i] = e.Message[match[2*i]:match[2*i+1]]
+			}
+		}
+		return r.suggest(engine, e, groups)
+	}
+	return string(r.messagePattern.ExpandString(nil, r.Hint, e.Message, match))
+}
+
+// Engine holds the rules in the order they are tried
+type Engine struct {
+	// Root is the project scanned for did-you-mean suggestions. Empty
+	// means the current directory.
+	Root string
+
+	rules   []*Rule
+	indexes map[string]*identifierIndex // Per language, built on first use
+}
+
+// New returns an engine with the built-in rules
+func New() *Engine {
+	e := &Engine{}
+	for i := range builtinRules {
+		rule := builtinRules[i]
+		if err := rule.compile(); err != nil {
+			panic(err)
+		}
+		e.rules = append(e.rules, &rule)
+	}
+	return e
+}
+
+// config is the format of the rules file
+type config struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Load adds the rules from a JSON config file. User rules are tried
+// before the built-in ones.
+func (e *Engine) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var cfg config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	for _, rule := range cfg.Rules {
+		if err := rule.compile(); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+
+	e.rules = append(cfg.Rules, e.rules...)
+	return nil
+}
+
+// Suggest returns the hints of every matching rule, without duplicates
+func (e *Engine) Suggest(err *errclean.CleanedError) []string {
+	var hints []string
+	seen := make(map[string]bool)
+
+	for _, rule := range e.rules {
+		hint := rule.apply(e, err)
+		if hint == "" || seen[hint] {
+			continue
+		}
+		seen[hint] = true
+		hints = append(hints, hint)
+	}
+	return hints
+}
+
+// DefaultConfigPath returns the location of the user's rules file,
+// e.g. ~/.config/err/rules.json
+func DefaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "err", "rules.json")
+}
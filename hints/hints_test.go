@@ -0,0 +1,169 @@
+package hints
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      errclean.CleanedError
+		expected []string
+	}{
+		{
+			name:     "pip install",
+			err:      errclean.CleanedError{Type: "ModuleNotFoundError", Message: "No module named 'requests'", Language: "python"},
+			expected: []string{"pip install requests (or activate the virtualenv it is installed in)"},
+		},
+		{
+			name:     "pip package differs from import",
+			err:      errclean.CleanedError{Type: "ModuleNotFoundError", Message: "No module named 'yaml.constructor'", Language: "python"},
+			expected: []string{"pip install PyYAML (or activate the virtualenv it is installed in)"},
+		},
+		{
+			name:     "npm ERESOLVE",
+			err:      errclean.CleanedError{Type: "npm ERESOLVE", Message: "unable to resolve dependency tree", Language: "javascript"},
+			expected: []string{"Peer dependency conflict: upgrade the package named after \"Could not resolve dependency\", or retry with npm install --legacy-peer-deps"},
+		},
+		{
+			name:     "npm install scoped package",
+			err:      errclean.CleanedError{Type: "Error", Message: "Cannot find module '@babel/core/lib/index'", Language: "javascript"},
+			expected: []string{"npm install @babel/core"},
+		},
+		{
+			name:     "go did-you-mean",
+			err:      errclean.CleanedError{Type: "build error", Message: "undefined: fmt.Printl", Language: "go"},
+			expected: []string{"Did you mean fmt.Println?"},
+		},
+		{
+			name:     "go did-you-mean with wrong case",
+			err:      errclean.CleanedError{Type: "build error", Message: "undefined: strings.Hasprefix", Language: "go"},
+			expected: []string{"Did you mean strings.HasPrefix?"},
+		},
+		{
+			name: "go undefined with no close match",
+			err:  errclean.CleanedError{Type: "build error", Message: "undefined: fmt.Frobnicate", Language: "go"},
+		},
+		{
+			name:     "rust E0382",
+			err:      errclean.CleanedError{Type: "E0382", Message: "borrow of moved value: `s`", Language: "rust"},
+			expected: []string{"Borrow with `&s` instead of moving it, or call `s.clone()` where it is moved"},
+		},
+		{
+			name: "language must match",
+			err:  errclean.CleanedError{Type: "KeyError", Message: "'id'", Language: "javascript"},
+		},
+	}
+
+	engine := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.Suggest(&tt.err); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Suggest() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	config := `{"rules": [
+		{"type": "npm E.*", "message": "registry\\.(\\w+)\\.internal", "hint": "Connect to the VPN for the $1 registry"},
+		{"type": "E0382", "hint": "See the team guide on ownership"}
+	]}`
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := New()
+	if err := engine.Load(path); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	got := engine.Suggest(&errclean.CleanedError{Type: "npm ETIMEDOUT", Message: "request to https://registry.corp.internal failed"})
+	if !reflect.DeepEqual(got, []string{"Connect to the VPN for the corp registry"}) {
+		t.Errorf("Suggest() = %q", got)
+	}
+
+	// User rules come first, built-in ones still apply
+	got = engine.Suggest(&errclean.CleanedError{Type: "E0382", Message: "use of moved value: `v`", Language: "rust"})
+	if len(got) != 2 || got[0] != "See the team guide on ownership" {
+		t.Errorf("Suggest() = %q", got)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(`{"rules": [{"message": "(", "hint": "x"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := New().Load(path); err == nil {
+		t.Error("Load() accepted an invalid pattern")
+	}
+}
+
+func TestSuggestIdentifier(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"src/main.rs":             "fn main() {\n    let counter = 1;\n    println!(\"{}\", countr);\n}\n",
+		"app/models.py":           "def load_users():\n    return []\n",
+		"web/index.js":            "const userName = 'a';\nconsole.log(usrName);\n",
+		"node_modules/x/index.js": "const userNames = 1;\n",
+		"main.go":                 "package main\n\nfunc handleRequest() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		err      errclean.CleanedError
+		expected []string
+	}{
+		{
+			name:     "rust",
+			err:      errclean.CleanedError{Type: "E0425", Message: "cannot find value `countr` in this scope", Language: "rust"},
+			expected: []string{"Did you mean `counter` (src/main.rs:2:9)?"},
+		},
+		{
+			name:     "python",
+			err:      errclean.CleanedError{Type: "NameError", Message: "name 'load_user' is not defined", Language: "python"},
+			expected: []string{"Did you mean `load_users` (app/models.py:1:5)?"},
+		},
+		{
+			name:     "javascript skips node_modules",
+			err:      errclean.CleanedError{Type: "ReferenceError", Message: "usrName is not defined", Language: "javascript"},
+			expected: []string{"Did you mean `userName` (web/index.js:1:7)?"},
+		},
+		{
+			name:     "go",
+			err:      errclean.CleanedError{Type: "build error", Message: "undefined: handleReqest", Language: "go"},
+			expected: []string{"Did you mean `handleRequest` (main.go:3:6)?"},
+		},
+		{
+			name: "nothing close",
+			err:  errclean.CleanedError{Type: "build error", Message: "undefined: somethingElse", Language: "go"},
+		},
+	}
+
+	engine := New()
+	engine.Root = root
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.Suggest(&tt.err); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Suggest() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package hints
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Limits that keep a scan of a large tree fast
+const (
+	maxScannedFiles = 5000
+	maxFileSize     = 1 << 20
+)
+
+var identifierPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// sourceExtensions lists the files scanned for each language
+var sourceExtensions = map[string][]string{
+	"go":         {".go"},
+	"rust":       {".rs"},
+	"python":     {".py"},
+	"javascript": {".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx", ".mts", ".cts"},
+}
+
+// skippedDirs are not project code, or are too big to be worth scanning
+var skippedDirs = map[string]bool{
+	".git":             true,
+	".hg":              true,
+	"node_modules":     true,
+	"bower_components": true,
+	"vendor":           true,
+	"target":           true,
+	"dist":             true,
+	"build":            true,
+	"__pycache__":      true,
+	"venv":             true,
+	".venv":            true,
+	"site-packages":    true,
+}
+
+// identifierIndex maps identifiers in a project to where they first appear
+type identifierIndex struct {
+	names     []string
+	locations map[string]errclean.Location
+}
+
+// identifiers returns the index for a language, scanning the project the
+// first time it is needed
+func (e *Engine) identifiers(language string) *identifierIndex {
+	if index, ok := e.indexes[language]; ok {
+		return index
+	}
+
+	index := scanIdentifiers(e.Root, sourceExtensions[language])
+	if e.indexes == nil {
+		e.indexes = make(map[string]*identifierIndex)
+	}
+	e.indexes[language] = index
+	return index
+}
+
+// scanIdentifiers collects the identifiers in the source files under root
+// with one of the given extensions
+func scanIdentifiers(root string, extensions []string) *identifierIndex {
+	index := &identifierIndex{locations: make(map[string]errclean.Location)}
+	if len(extensions) == 0 {
+		return index
+	}
+	if root == "" {
+		root = "."
+	}
+
+	files := 0
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (skippedDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !hasExtension(path, extensions) {
+			return nil
+		}
+		if files++; files > maxScannedFiles {
+			return filepath.SkipAll
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+		index.scanFile(path, filepath.ToSlash(rel))
+		return nil
+	})
+
+	return index
+}
+
+func (index *identifierIndex) scanFile(path, rel string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > maxFileSize {
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxFileSize)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		for _, match := range identifierPattern.FindAllStringIndex(text, -1) {
+			name := text[match[0]:match[1]]
+			if _, ok := index.locations[name]; ok {
+				continue
+			}
+			index.names = append(index.names, name)
+			index.locations[name] = errclean.Location{File: rel, Line: line, Column: match[0] + 1}
+		}
+	}
+}
+
+func hasExtension(path string, extensions []string) bool {
+	ext := filepath.Ext(path)
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestIdentifier returns a did-you-mean hint for an unknown identifier,
+// listing the closest names in the project with their locations
+func suggestIdentifier(engine *Engine, e *errclean.CleanedError, name string) string {
+	index := engine.identifiers(e.Language)
+	matches := nearest(name, index.names, 3)
+	if len(matches) == 0 {
+		return ""
+	}
+
+	suggestions := make([]string, len(matches))
+	for i, match := range matches {
+		suggestions[i] = "`" + match + "` (" + index.locations[match].String() + ")"
+	}
+	return "Did you mean " + strings.Join(suggestions, " or ") + "?"
+}
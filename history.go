@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/XD637/err/errclean"
+)
+
+// runEntry remembers where a diagnostic from the last run points to
+type runEntry struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"` // Absolute path
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// newRunEntry records where a diagnostic points to
+func newRunEntry(result *errclean.CleanedError, root string) runEntry {
+	entry := runEntry{Type: result.Type, Message: result.Message}
+	if loc := result.PrimaryLocation(); !loc.IsZero() {
+		entry.File = errclean.AbsPath(root, loc.File)
+		entry.Line = loc.Line
+		entry.Column = loc.Column
+	}
+	return entry
+}
+
+// lastRunPath returns the file where the last run's diagnostics are stored
+func lastRunPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "err", "last-run.json"), nil
+}
+
+// saveLastRun stores the locations of the reported diagnostics, in output
+// order, so that `err open N` can jump to them
+func saveLastRun(results []*errclean.CleanedError, root string) error {
+	path, err := lastRunPath()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]runEntry, 0, len(results))
+	for _, result := range results {
+		entries = append(entries, newRunEntry(result, root))
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// loadLastRun reads the diagnostics stored by the previous run
+func loadLastRun() ([]runEntry, error) {
+	path, err := lastRunPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []runEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
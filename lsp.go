@@ -0,0 +1,461 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XD637/err/errclean"
+)
+
+// rpcRequest is an incoming JSON-RPC request or notification
+type rpcRequest struct {
+	ID     *json.RawMessage `json:"id"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params"`
+}
+
+// rpcResponse is a successful JSON-RPC response. Result is always
+// present, since "shutdown" must answer with null.
+type rpcResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  any              `json:"result"`
+}
+
+// rpcErrorResponse is a failed JSON-RPC response
+type rpcErrorResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Error   rpcError         `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcNotification is an outgoing JSON-RPC notification
+type rpcNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// JSON-RPC error codes
+const (
+	rpcMethodNotFound = -32601
+	rpcInvalidRequest = -32600
+)
+
+// lspPosition and lspRange are zero-based, as in the protocol
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+// lspDiagnostic is a textDocument/publishDiagnostics entry
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code,omitempty"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type publishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+// lspSeverities maps severities to LSP DiagnosticSeverity values
+var lspSeverities = map[errclean.Severity]int{
+	errclean.SeverityError:   1,
+	errclean.SeverityWarning: 2,
+	errclean.SeverityNote:    3,
+	errclean.SeverityHelp:    4,
+}
+
+// lspServer publishes cleaned diagnostics to an editor over the Language
+// Server Protocol. It runs a build command, or re-reads a log file, and
+// reports the errors against the files in the workspace.
+type lspServer struct {
+	in  *bufio.Reader
+	out io.Writer
+	mu  sync.Mutex // Serializes writes to out
+
+	format  string
+	command string
+	logFile string
+	root    string
+
+	publishMu sync.Mutex      // Serializes publish, called by the worker and the log watcher
+	published map[string]bool // URIs that currently have diagnostics
+	trigger   chan struct{}
+	shutdown  bool
+}
+
+// runLSP implements `err lsp`
+func runLSP(args []string) int {
+	fs := flag.NewFlagSet("lsp", flag.ContinueOnError)
+	command := fs.String("command", "", "build or test command to run on start and on save")
+	logFile := fs.String("log", "", "log file to watch instead of running a command")
+	format := fs.String("format", "auto", formatUsage())
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	server := &lspServer{
+		in:        bufio.NewReader(os.Stdin),
+		out:       os.Stdout,
+		format:    *format,
+		command:   *command,
+		logFile:   *logFile,
+		published: make(map[string]bool),
+		trigger:   make(chan struct{}, 1),
+	}
+	return server.serve()
+}
+
+// serve reads requests until "exit" and returns the process exit code
+func (s *lspServer) serve() int {
+	go s.worker()
+
+	for {
+		req, err := s.read()
+		if err != nil {
+			if err != io.EOF {
+				fmt.Fprintf(os.Stderr, "err lsp: %v\n", err)
+			}
+			return 1
+		}
+
+		switch req.Method {
+		case "initialize":
+			s.initialize(req)
+		case "initialized", "textDocument/didSave":
+			s.run()
+		case "shutdown":
+			s.shutdown = true
+			s.reply(req.ID, nil)
+		case "exit":
+			if s.shutdown {
+				return 0
+			}
+			return 1
+		default:
+			// Notifications we don't handle are ignored; requests get an error
+			if req.ID != nil {
+				s.replyError(req.ID, rpcMethodNotFound, "method not supported: "+req.Method)
+			}
+		}
+	}
+}
+
+// initialize reads the workspace root and options, and reports capabilities
+func (s *lspServer) initialize(req *rpcRequest) {
+	var params struct {
+		RootURI               string `json:"rootUri"`
+		RootPath              string `json:"rootPath"`
+		InitializationOptions struct {
+			Command string `json:"command"`
+			LogFile string `json:"logFile"`
+			Format  string `json:"format"`
+		} `json:"initializationOptions"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.replyError(req.ID, rpcInvalidRequest, err.Error())
+		return
+	}
+
+	switch {
+	case params.RootURI != "":
+		s.root = uriToPath(params.RootURI)
+	case params.RootPath != "":
+		s.root = params.RootPath
+	default:
+		s.root, _ = os.Getwd()
+	}
+
+	// Command-line flags take precedence over editor settings
+	opts := params.InitializationOptions
+	if s.command == "" {
+		s.command = opts.Command
+	}
+	if s.logFile == "" {
+		s.logFile = opts.LogFile
+	}
+	if s.format == "auto" && opts.Format != "" {
+		s.format = opts.Format
+	}
+
+	if s.logFile != "" {
+		go s.watchLog()
+	}
+
+	s.reply(req.ID, map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync": map[string]any{
+				"openClose": true,
+				"save":      map[string]any{"includeText": false},
+			},
+		},
+		"serverInfo": map[string]any{"name": "err", "version": version},
+	})
+}
+
+// run schedules a run of the build command. Saves during a run queue a
+// single re-run instead of piling up.
+func (s *lspServer) run() {
+	if s.command == "" {
+		return
+	}
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// worker runs the build command whenever it is triggered
+func (s *lspServer) worker() {
+	for range s.trigger {
+		output := runShell(s.command, s.root)
+		s.publish(output)
+	}
+}
+
+// watchLog re-reads the log file whenever it changes. Polling works on
+// every platform and file system.
+func (s *lspServer) watchLog() {
+	path := errclean.AbsPath(s.root, s.logFile)
+	var lastMod time.Time
+
+	for {
+		if info, err := os.Stat(path); err == nil && !info.ModTime().Equal(lastMod) {
+			lastMod = info.ModTime()
+			if data, err := os.ReadFile(path); err == nil {
+				s.publish(string(data))
+			}
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// publish cleans the output and sends diagnostics for every affected file,
+// clearing files that no longer have errors
+func (s *lspServer) publish(output string) {
+	s.publishMu.Lock()
+	defer s.publishMu.Unlock()
+
+	cleaner := NewCleaner(s.format)
+	cleaner.Root = s.root
+	byURI := lspDiagnostics(cleaner.CleanAll(output), s.root)
+
+	uris := make([]string, 0, len(byURI))
+	for uri := range byURI {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	for _, uri := range uris {
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: byURI[uri]})
+	}
+
+	for uri := range s.published {
+		if _, ok := byURI[uri]; !ok {
+			s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: []lspDiagnostic{}})
+		}
+	}
+
+	s.published = make(map[string]bool)
+	for uri := range byURI {
+		s.published[uri] = true
+	}
+}
+
+// lspDiagnostics groups cleaned errors by the file URI of their location.
+// Errors without a location in the workspace can't be shown next to the
+// code that caused them and are dropped.
+func lspDiagnostics(results []*errclean.CleanedError, root string) map[string][]lspDiagnostic {
+	byURI := make(map[string][]lspDiagnostic)
+
+	for _, result := range results {
+		loc := workspaceLocation(result, root)
+		if loc.IsZero() {
+			continue
+		}
+
+		line, col := loc.Line-1, loc.Column-1
+		if line < 0 {
+			line = 0
+		}
+		if col < 0 {
+			col = 0
+		}
+
+		message := result.Message
+		if result.Test != "" {
+			message = result.Test + ": " + message
+		}
+		if len(result.Details) > 0 {
+			message += "\n" + strings.Join(result.Details, "\n")
+		}
+
+		uri := pathToURI(errclean.AbsPath(root, loc.File))
+		byURI[uri] = append(byURI[uri], lspDiagnostic{
+			Range: lspRange{
+				Start: lspPosition{Line: line, Character: col},
+				End:   lspPosition{Line: line, Character: col + 1},
+			},
+			Severity: lspSeverities[result.Severity],
+			Code:     result.Type,
+			Source:   "err",
+			Message:  message,
+		})
+	}
+
+	return byURI
+}
+
+// workspaceLocation returns the location of an error, or of the first
+// stack frame, that is inside root. An error in a dependency or the
+// standard library is reported where project code led to it.
+func workspaceLocation(result *errclean.CleanedError, root string) errclean.Location {
+	candidates := []errclean.Location{result.PrimaryLocation()}
+	for _, frame := range result.Stack {
+		if frame.Kind == errclean.FrameUser {
+			candidates = append(candidates, frame.Location)
+		}
+	}
+
+	for _, loc := range candidates {
+		if loc.IsZero() {
+			continue
+		}
+		rel, err := filepath.Rel(root, errclean.AbsPath(root, loc.File))
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return loc
+		}
+	}
+	return errclean.Location{}
+}
+
+// read reads one Content-Length framed message
+func (s *lspServer) read() (*rpcRequest, error) {
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if value, ok := strings.CutPrefix(line, "Content-Length:"); ok {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length: %v", err)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, err
+	}
+
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("invalid message: %v", err)
+	}
+	return &req, nil
+}
+
+// write sends one Content-Length framed message
+func (s *lspServer) write(msg any) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "err lsp: %v\n", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func (s *lspServer) reply(id *json.RawMessage, result any) {
+	s.write(rpcResponse{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *lspServer) replyError(id *json.RawMessage, code int, message string) {
+	s.write(rpcErrorResponse{JSONRPC: "2.0", ID: id, Error: rpcError{Code: code, Message: message}})
+}
+
+func (s *lspServer) notify(method string, params any) {
+	s.write(rpcNotification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// runShell runs a command through the platform shell and returns its
+// combined output. A failing command is expected: that's where errors come from.
+func runShell(command, dir string) string {
+	cmd := shellCommand(command)
+	cmd.Dir = dir
+
+	output, _ := cmd.CombinedOutput()
+	return string(output)
+}
+
+// shellCommand returns a command that runs a command line through the
+// platform shell
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// pathToURI converts an absolute path to a file:// URI
+func pathToURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		// Windows drive letter: C:/src -> /C:/src
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// uriToPath converts a file:// URI to a local path
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+	return filepath.FromSlash(path)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestLSPDiagnostics(t *testing.T) {
+	results := []*errclean.CleanedError{
+		{
+			Type:     "E0382",
+			Message:  "borrow of moved value: `s`",
+			Severity: errclean.SeverityError,
+			Location: errclean.Location{File: "src/main.rs", Line: 5, Column: 20},
+			Details:  []string{"help: consider cloning the value"},
+		},
+		{
+			Type:     "unused_variables",
+			Message:  "unused variable: `x`",
+			Severity: errclean.SeverityWarning,
+			Location: errclean.Location{File: "src/main.rs", Line: 2, Column: 9},
+		},
+		{
+			Type:    "panic",
+			Message: "no location",
+		},
+		{
+			Type:     "JSONDecodeError",
+			Message:  "Expecting value",
+			Location: errclean.Location{File: "/usr/lib/python3.11/json/decoder.py", Line: 355},
+			Stack: []errclean.Frame{
+				{Location: errclean.Location{File: "/app/src/load.py", Line: 8}},
+				{Location: errclean.Location{File: "/usr/lib/python3.11/json/decoder.py", Line: 355}, Kind: errclean.FrameRuntime},
+			},
+		},
+		{
+			Type:     "TypeError",
+			Message:  "only outside the workspace",
+			Location: errclean.Location{File: "/home/dev/.cargo/registry/src/serde-1.0/src/de.rs", Line: 3},
+		},
+	}
+
+	byURI := lspDiagnostics(results, "/app")
+	if len(byURI) != 2 {
+		t.Fatalf("expected 2 files, got %d: %v", len(byURI), byURI)
+	}
+	if diags := byURI["file:///app/src/load.py"]; len(diags) != 1 || diags[0].Range.Start.Line != 7 {
+		t.Errorf("error outside the workspace should move to the project frame, got %v", diags)
+	}
+
+	diags := byURI["file:///app/src/main.rs"]
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(diags))
+	}
+
+	first := diags[0]
+	if first.Range.Start != (lspPosition{Line: 4, Character: 19}) {
+		t.Errorf("range start = %+v, want 4:19", first.Range.Start)
+	}
+	if first.Severity != 1 || first.Code != "E0382" {
+		t.Errorf("severity/code = %d/%s, want 1/E0382", first.Severity, first.Code)
+	}
+	if first.Message != "borrow of moved value: `s`\nhelp: consider cloning the value" {
+		t.Errorf("message = %q", first.Message)
+	}
+	if diags[1].Severity != 2 {
+		t.Errorf("warning severity = %d, want 2", diags[1].Severity)
+	}
+}
+
+func TestLSPSession(t *testing.T) {
+	messages := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"rootUri":"file:///app"}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/hover","params":{}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	}
+	var input strings.Builder
+	for _, msg := range messages {
+		fmt.Fprintf(&input, "Content-Length: %d\r\n\r\n%s", len(msg), msg)
+	}
+
+	var output bytes.Buffer
+	server := &lspServer{
+		in:        bufio.NewReader(strings.NewReader(input.String())),
+		out:       &output,
+		format:    "auto",
+		published: make(map[string]bool),
+		trigger:   make(chan struct{}, 1),
+	}
+	if code := server.serve(); code != 0 {
+		t.Errorf("exit code = %d, want 0", code)
+	}
+	if server.root != "/app" {
+		t.Errorf("root = %q, want /app", server.root)
+	}
+
+	// Read the replies back through the same framing
+	reader := &lspServer{in: bufio.NewReader(&output)}
+	body := output.String()
+	replies := 0
+	for {
+		req, err := reader.read()
+		if err != nil {
+			break
+		}
+		if req.ID == nil {
+			t.Fatalf("unexpected notification %q", req.Method)
+		}
+		replies++
+	}
+	if replies != 3 {
+		t.Fatalf("expected 3 replies, got %d:\n%s", replies, body)
+	}
+
+	for _, want := range []string{`"textDocumentSync"`, `"code":-32601`, `"id":3,"result":null`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("output missing %s:\n%s", want, body)
+		}
+	}
+}
+
+func TestLSPConcurrentPublish(t *testing.T) {
+	var out bytes.Buffer
+	server := &lspServer{out: &out, format: "auto", root: "/app", published: make(map[string]bool)}
+
+	// The build command and the log watcher publish from their own goroutines
+	done := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		go func(i int) {
+			for j := 0; j < 20; j++ {
+				server.publish(fmt.Sprintf("./main.go:%d:2: undefined: x", i+1))
+			}
+			done <- struct{}{}
+		}(i)
+	}
+	<-done
+	<-done
+
+	if len(server.published) != 1 {
+		t.Errorf("published = %v, want the last run's file", server.published)
+	}
+}
@@ -5,6 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/explain"
+	"github.com/XD637/err/hints"
 )
 
 const version = "0.1.0"
@@ -13,10 +18,39 @@ var (
 	flagVersion = flag.Bool("version", false, "print version")
 	flagHelp    = flag.Bool("help", false, "print help")
 	flagVerbose = flag.Bool("v", false, "verbose output")
-	flagFormat  = flag.String("format", "auto", "error format (auto|javascript|python|go|rust)")
+	flagFormat  = flag.String("format", "auto", formatUsage())
+	flagMinSev  = flag.String("min-severity", "warning", "lowest severity to report (error|warning|note|help)")
+	flagFrames  = flag.String("frames", "user", "stack frames to show (user|all|N)")
+	flagRoot    = flag.String("root", "", "project root used to classify frames (default: current directory)")
+	flagSource  = flag.Bool("source", true, "show source code around the error location")
+	flagContext = flag.Int("context", 1, "lines of source context around the error line")
+	flagLinks   = flag.String("link-template", "file://{abs}", "URL template for terminal hyperlinks on locations (empty to disable)")
+	flagOutput  = flag.String("output", "text", "output format (text|quickfix)")
+	flagAbs     = flag.Bool("abs", false, "print absolute paths in quickfix output")
+	flagExplain = flag.Bool("explain", false, "explain each error code and suggest common fixes")
+	flagHints   = flag.Bool("hints", true, "suggest next steps for each error")
+	flagTUI     = flag.Bool("i", false, "browse the errors in an interactive full-screen view")
+	flagRules   = flag.String("rules", "", "JSON file with extra hint rules (default: ~/.config/err/rules.json)")
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "open" {
+		os.Exit(runOpen(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "lsp" {
+		os.Exit(runLSP(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		os.Exit(runWatch(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(runDiffCommand(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		os.Exit(runExplain(os.Args[2:]))
+	}
+
 	flag.Parse()
 
 	if *flagVersion {
@@ -29,6 +63,23 @@ func main() {
 		os.Exit(0)
 	}
 
+	minSeverity, err := errclean.ParseSeverity(*flagMinSev)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	frameFilter, err := errclean.ParseFrameFilter(*flagFrames)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *flagOutput != "text" && *flagOutput != "quickfix" {
+		fmt.Fprintf(os.Stderr, "error: unknown output format %q (want text or quickfix)\n", *flagOutput)
+		os.Exit(1)
+	}
+
 	args := flag.Args()
 	var data string
 
@@ -59,7 +110,24 @@ func main() {
 
 	// Process the error
 	cleaner := NewCleaner(*flagFormat)
-	result := cleaner.Clean(data)
+	cleaner.Root = *flagRoot
+	cleaner.Frames = frameFilter
+	cleaner.Snippets = *flagSource
+	cleaner.Context = *flagContext
+	if *flagHints {
+		engine, err := loadHints(*flagRules, *flagRoot)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		cleaner.Hints = engine
+	}
+	all := cleaner.CleanAll(data)
+	if *flagTUI {
+		// The view filters by severity itself, starting at -min-severity
+		os.Exit(runTUI(all, data, minSeverity, *flagRoot))
+	}
+	results := errclean.FilterSeverity(all, minSeverity)
 
 	// Add separator in interactive mode
 	if len(args) == 0 {
@@ -69,35 +137,207 @@ func main() {
 		}
 	}
 
+	// Hyperlinks only make sense when a terminal renders the output
+	formatOpts := errclean.FormatOptions{Root: *flagRoot}
+	if stdoutIsTerminal() {
+		formatOpts.LinkTemplate = *flagLinks
+	}
+
 	// Output
-	if *flagVerbose {
-		fmt.Printf("Type: %s\n", result.Type)
-		fmt.Printf("Message: %s\n", result.Message)
-		if len(result.Stack) > 0 {
-			fmt.Println("\nStack:")
-			for _, frame := range result.Stack {
-				fmt.Printf("  %s\n", frame)
+	for i, result := range results {
+		if *flagOutput == "quickfix" {
+			fmt.Println(result.QuickfixLine(*flagRoot, *flagAbs))
+			continue
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		if *flagVerbose {
+			printVerbose(result)
+		} else {
+			output := result.FormatWith(formatOpts)
+			fmt.Print(output)
+			if !strings.HasSuffix(output, "\n") {
+				fmt.Println()
+			}
+			if *flagExplain {
+				printExplanation(result)
 			}
 		}
-	} else {
-		fmt.Print(result.Format())
+	}
+
+	// Remember the locations for `err open`; failing to do so is not fatal
+	_ = saveLastRun(results, *flagRoot)
+}
+
+// printVerbose prints the structured fields of a cleaned error
+func printVerbose(result *errclean.CleanedError) {
+	if result.Test != "" {
+		fmt.Printf("Test: %s\n", result.Test)
+	}
+	fmt.Printf("Type: %s\n", result.Type)
+	fmt.Printf("Severity: %s\n", result.Severity)
+	fmt.Printf("Message: %s\n", result.Message)
+	if !result.Location.IsZero() {
+		fmt.Printf("Location: %s\n", result.Location)
+	}
+	if len(result.Details) > 0 {
+		fmt.Println("\nDetails:")
+		for _, detail := range result.Details {
+			fmt.Printf("  %s\n", detail)
+		}
+	}
+	if len(result.Hints) > 0 {
+		fmt.Println("\nHints:")
+		for _, hint := range result.Hints {
+			fmt.Printf("  %s\n", hint)
+		}
+	}
+	if result.Source != nil {
+		fmt.Println("\nSource:")
+		for _, line := range result.Source.Format() {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	if len(result.Stack) > 0 {
+		fmt.Println("\nStack:")
+		for _, frame := range result.Stack {
+			fmt.Printf("  [%s] %s\n", frame.Kind, frame.Text)
+		}
+	}
+	if *flagExplain {
+		if entry := explain.ForError(result); entry != nil {
+			fmt.Println("\nExplanation:")
+			for _, line := range entry.Format() {
+				fmt.Printf("  %s\n", line)
+			}
+		}
+	}
+}
+
+// loadHints returns the hint engine with the user's rules from path, or
+// from the default config file if it exists
+func loadHints(path, root string) (*hints.Engine, error) {
+	engine := hints.New()
+	engine.Root = root
+	if path == "" {
+		path = hints.DefaultConfigPath()
+		if _, err := os.Stat(path); err != nil {
+			return engine, nil
+		}
+	}
+	if err := engine.Load(path); err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
+
+// printExplanation prints the knowledge base entry for an error, if any
+func printExplanation(result *errclean.CleanedError) {
+	entry := explain.ForError(result)
+	if entry == nil {
+		return
+	}
+	fmt.Println()
+	for _, line := range entry.Format() {
+		fmt.Printf("  %s\n", line)
 	}
 }
 
 func printHelp() {
-	fmt.Println(`err - clean and normalize error messages
+	fmt.Printf(`err - clean and normalize error messages
 
 USAGE
     err [OPTIONS] [FILE]
+    err open [N]
+    err lsp [-command CMD | -log FILE] [-format FORMAT]
+    err explain CODE
+    err diff [-output text|json] BEFORE.log AFTER.log
+    err watch [-interval 1s] [-format FORMAT] -- COMMAND
 
     Read error messages from FILE or stdin, strip noise, and output
     clean, normalized error information.
 
+    "err open N" opens the location of the Nth error from the last run
+    in $VISUAL or $EDITOR (default: the first error).
+
+    "err lsp" runs a language server on stdin/stdout. It runs CMD on
+    start and on every save (or re-reads FILE when it changes) and
+    publishes the cleaned errors as editor diagnostics. The command,
+    log file and format can also be set through the editor's
+    initializationOptions: {"command": "...", "logFile": "...", "format": "..."}
+
+    "err watch -- COMMAND" runs COMMAND, shows its cleaned errors and
+    runs it again whenever a file in the tree changes. Errors are marked
+    as new, unchanged or fixed compared to the previous run.
+
+    "err diff BEFORE AFTER" compares the errors of two logs and reports
+    which were added, resolved or persist. Like diff(1), it exits with 1
+    when errors were added and 2 on trouble.
+
+    "err explain CODE" explains an error code or message offline, e.g.
+    E0382, TS2322, ERESOLVE, KeyError or "assignment to entry in nil map".
+
 OPTIONS
     -format string
-        Error format: auto, javascript, python, java, go, rust
+        Error format: %s
         Default: auto (detect automatically)
     
+    -min-severity string
+        Lowest severity to report: error, warning, note, help
+        Default: warning
+
+    -frames string
+        Stack frames to show: user (collapse dependency and runtime
+        frames), all, or a maximum number of frames
+        Default: user
+
+    -root string
+        Project root used to tell user code from dependencies
+        Default: current directory
+
+    -source
+        Show source code around the error location when the file
+        exists under the project root. Disable with -source=false
+        Default: true
+
+    -context int
+        Lines of source context before and after the error line
+        Default: 1
+
+    -link-template string
+        URL template for terminal hyperlinks on file locations, using
+        {abs}, {file}, {line} and {col}. Empty disables hyperlinks.
+        Example: vscode://file/{abs}:{line}:{col}
+        Default: file://{abs}
+
+    -output string
+        Output format: text, or quickfix for "file:line:col: type: message"
+        lines that Vim (:cfile, :cexpr) and Emacs compilation-mode read
+        Default: text
+
+    -abs
+        Print absolute paths in quickfix output instead of paths
+        relative to the project root
+
+    -explain
+        Explain each error code and suggest common fixes, using the
+        bundled offline knowledge base
+
+    -hints
+        Suggest a next step for each error, e.g. the package to install
+        or the function you probably meant. Disable with -hints=false
+        Default: true
+
+    -rules string
+        JSON file with extra hint rules, tried before the built-in ones
+        Default: ~/.config/err/rules.json (if it exists)
+
+    -i  Browse the errors in a full-screen view, grouped by file or
+        type, with search, severity filter and open-in-editor. Keys:
+        j/k move, J/K scroll details, / search, s severity, g group,
+        o open in $EDITOR, q quit
+
     -v  Verbose output with structured fields
     
     -version
@@ -124,6 +364,25 @@ EXAMPLES
     # Specific format
     err -format python < traceback.txt
 
+    # Load errors into Vim's quickfix list
+    :cexpr system('go build ./... 2>&1 \| err -output quickfix')
+
+    # Re-run the tests on every save
+    err watch -- go test ./...
+
+    # Triage a large build interactively
+    cargo build 2>&1 | err -i
+
+    # What did this branch break?
+    err diff main.log branch.log
+
+    # What does this code mean?
+    err explain E0382
+
+    # Jump to the second error in your editor
+    cargo build 2>&1 | err
+    err open 2
+
 OUTPUT
     Cleaned error with:
     - Type and message extracted
@@ -132,5 +391,6 @@ OUTPUT
     - Duplicates removed
 
 DOCUMENTATION
-    https://github.com/XD637/err`)
+    https://github.com/XD637/err
+`, strings.Join(formatNames(), ", "))
 }
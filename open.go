@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// runOpen implements `err open [N]`: it opens the Nth diagnostic of the
+// last run in $VISUAL or $EDITOR
+func runOpen(args []string) int {
+	n := 1
+	if len(args) > 0 {
+		var err error
+		n, err = strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			fmt.Fprintf(os.Stderr, "error: invalid diagnostic number %q\n", args[0])
+			return 1
+		}
+	}
+
+	entries, err := loadLastRun()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: no previous run to open: %v\n", err)
+		return 1
+	}
+
+	if n > len(entries) {
+		fmt.Fprintf(os.Stderr, "error: the last run reported %d diagnostics\n", len(entries))
+		return 1
+	}
+
+	entry := entries[n-1]
+	if entry.File == "" {
+		fmt.Fprintf(os.Stderr, "error: diagnostic %d (%s) has no location\n", n, entry.Type)
+		return 1
+	}
+
+	cmd := editorCommand(userEditor(), entry)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// userEditor returns the editor from $VISUAL or $EDITOR, or vi
+func userEditor() string {
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	return "vi"
+}
+
+// editorCommand builds the command that opens an entry's file at its line
+// and column, using the argument style the editor understands
+func editorCommand(editor string, entry runEntry) *exec.Cmd {
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		// EDITOR set to whitespace only
+		fields = []string{"vi"}
+	}
+	name := strings.TrimSuffix(filepath.Base(fields[0]), ".exe")
+	args := fields[1:]
+
+	line, col := entry.Line, entry.Column
+	if line < 1 {
+		line = 1
+	}
+	if col < 1 {
+		col = 1
+	}
+
+	switch name {
+	case "code", "code-insiders", "codium", "cursor":
+		args = append(args, "--goto", fmt.Sprintf("%s:%d:%d", entry.File, line, col))
+	case "subl", "zed", "hx", "helix":
+		args = append(args, fmt.Sprintf("%s:%d:%d", entry.File, line, col))
+	case "idea", "goland", "pycharm", "webstorm", "rustrover", "clion", "phpstorm", "rubymine":
+		args = append(args, "--line", strconv.Itoa(line), "--column", strconv.Itoa(col), entry.File)
+	default:
+		// vi, vim, nvim, nano, emacs, micro, kak, ...
+		args = append(args, "+"+strconv.Itoa(line), entry.File)
+	}
+
+	return exec.Command(fields[0], args...)
+}
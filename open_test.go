@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	entry := runEntry{File: "/app/src/main.rs", Line: 5, Column: 20}
+
+	tests := []struct {
+		editor   string
+		expected string
+	}{
+		{"vim", "vim +5 /app/src/main.rs"},
+		{"emacs -nw", "emacs -nw +5 /app/src/main.rs"},
+		{"code --wait", "code --wait --goto /app/src/main.rs:5:20"},
+		{"/usr/local/bin/subl", "/usr/local/bin/subl /app/src/main.rs:5:20"},
+		{"idea", "idea --line 5 --column 20 /app/src/main.rs"},
+		{" ", "vi +5 /app/src/main.rs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.editor, func(t *testing.T) {
+			cmd := editorCommand(tt.editor, entry)
+			if got := strings.Join(cmd.Args, " "); got != tt.expected {
+				t.Errorf("editorCommand() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package dotnet
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// MSBuild canonical diagnostic format, also used by dotnet build:
+// "/src/App/Program.cs(12,5): error CS0103: The name 'foo' does not exist in the current context [/src/App/App.csproj]"
+// "/src/App/App.csproj : error NU1101: Unable to find package Foo. [/src/App/App.csproj]"
+// "MSBUILD : error MSB1009: Project file does not exist."
+var diagnosticPattern = regexp.MustCompile(`^(?:(.+?)(?:\((\d+)(?:,(\d+))?(?:,\d+,\d+)?\))?\s?: )?(error|warning|info) ([A-Z]+\d+)\s?: (.*?)(?: \[([^\]]+)\])?$`)
+
+// severities maps MSBuild levels to diagnostic severities
+var severities = map[string]errclean.Severity{
+	"error":   errclean.SeverityError,
+	"warning": errclean.SeverityWarning,
+	"info":    errclean.SeverityNote,
+}
+
+// parseDiagnostics returns one diagnostic per MSBuild error or warning, or
+// nil if the output has none. The "Build FAILED." summary repeats every
+// diagnostic, so repeats are dropped.
+func parseDiagnostics(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	seen := make(map[string]bool)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		matches := diagnosticPattern.FindStringSubmatch(trimmed)
+		if matches == nil {
+			continue
+		}
+
+		if seen[trimmed] {
+			continue
+		}
+		seen[trimmed] = true
+
+		result := &errclean.CleanedError{
+			Type:     matches[5],
+			Message:  errclean.StripNoise(matches[6]),
+			Severity: severities[matches[4]],
+		}
+
+		// Tools report without a source location: "MSBUILD : error ..."
+		if file := matches[1]; file != "" && (matches[2] != "" || strings.ContainsAny(file, `/\.`)) {
+			lineNum, _ := strconv.Atoi(matches[2])
+			column, _ := strconv.Atoi(matches[3])
+			result.Location = errclean.Location{File: file, Line: lineNum, Column: column}
+			result.Stack = []errclean.Frame{errclean.NewFrame(result.Location.String(), result.Location)}
+		}
+		if project := matches[7]; project != "" && !strings.HasSuffix(matches[1], project) {
+			result.Details = append(result.Details, "in project "+errclean.StripNoise(project))
+		}
+
+		results = append(results, result)
+	}
+
+	errclean.SortBySeverity(results)
+	return results
+}
@@ -0,0 +1,160 @@
+package dotnet
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles .NET exceptions and MSBuild / dotnet build diagnostics
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+var (
+	// Exception line: "Unhandled exception. System.NullReferenceException: Object reference not set ..."
+	// Exception names are namespaced, which keeps "Error: x" lines of other languages out
+	exceptionPattern = regexp.MustCompile(`^(?:Unhandled [Ee]xception\.\s+|Unhandled exception: )?((?:[A-Za-z_]\w*\.)+[A-Za-z_]\w*(?:Exception|Error))(?:: (.*))?$`)
+
+	// Inner exception: " ---> System.IO.FileNotFoundException: Could not find file ..."
+	innerPattern = regexp.MustCompile(`^---> ((?:[A-Za-z_]\w*\.)*[A-Za-z_]\w*)(?:: (.*))?$`)
+
+	// Stack frame: "at MyApp.UserService.GetName(Int32 id) in /src/MyApp/UserService.cs:line 42"
+	framePattern = regexp.MustCompile(`^at (.+?)(?: in (.+):line (\d+))?$`)
+)
+
+func (p *Parser) Name() string {
+	return "dotnet"
+}
+
+func (p *Parser) Detect(text string) int {
+	best := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "Unhandled exception. ") || strings.HasPrefix(trimmed, "--- End of inner exception stack trace ---"):
+			return 100
+		case diagnosticPattern.MatchString(trimmed) && (strings.Contains(trimmed, ": error CS") || strings.Contains(trimmed, ": warning CS") || strings.Contains(trimmed, ".csproj]")):
+			return 100
+		case framePattern.MatchString(trimmed) && strings.Contains(trimmed, ":line "):
+			return 95
+		case diagnosticPattern.MatchString(trimmed):
+			best = max(best, 80)
+		case exceptionPattern.MatchString(trimmed) && strings.HasPrefix(trimmed, "System."):
+			best = max(best, 80)
+		}
+	}
+	return best
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per MSBuild error or warning, or per
+// exception
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	lines := strings.Split(text, "\n")
+
+	if results := parseDiagnostics(lines); len(results) > 0 {
+		return results
+	}
+	if results := parseExceptions(lines); len(results) > 0 {
+		return results
+	}
+	return []*errclean.CleanedError{{Type: "error", Message: errclean.StripNoise(strings.TrimSpace(text))}}
+}
+
+// parseExceptions returns one diagnostic per exception. Inner exceptions
+// become details of the exception that wraps them. Their frames come
+// first, as .NET prints them first: the stack reads from the original
+// throw to the outermost caller.
+func parseExceptions(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if current != nil {
+			if matches := innerPattern.FindStringSubmatch(trimmed); matches != nil {
+				inner := matches[1]
+				if matches[2] != "" {
+					inner += ": " + errclean.StripNoise(matches[2])
+				}
+				current.Details = append(current.Details, "Inner exception: "+inner)
+				continue
+			}
+
+			if matches := framePattern.FindStringSubmatch(trimmed); matches != nil {
+				current.Stack = append(current.Stack, parseFrame(matches))
+				continue
+			}
+
+			if strings.HasPrefix(trimmed, "--- End of ") {
+				// "--- End of inner exception stack trace ---" or
+				// "--- End of stack trace from previous location ---"
+				continue
+			}
+		}
+
+		if matches := exceptionPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{
+				Type:    matches[1],
+				Message: errclean.StripNoise(matches[2]),
+			}
+			results = append(results, current)
+			continue
+		}
+
+		if current != nil && trimmed == "" {
+			current = nil
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// parseFrame builds a frame from the matches of framePattern. Frames of
+// code without debug symbols have no location.
+func parseFrame(matches []string) errclean.Frame {
+	var location errclean.Location
+	text := matches[1]
+	if matches[2] != "" {
+		lineNum, _ := strconv.Atoi(matches[3])
+		location = errclean.Location{File: matches[2], Line: lineNum}
+		text += " in " + location.String()
+	}
+
+	frame := errclean.NewFrame(text, location)
+	if i := strings.Index(matches[1], "("); i > 0 {
+		frame.Function = matches[1][:i]
+	}
+	frame.Kind = frameKind(frame.Function)
+	return frame
+}
+
+// Namespaces of the base class library and ASP.NET Core. The parser
+// decides, not the classifier, because other languages have their own
+// System and Microsoft namespaces in user code.
+var runtimeNamespaces = []string{"System.", "Microsoft."}
+
+// frameKind returns where the code of a method comes from
+func frameKind(function string) errclean.FrameKind {
+	for _, prefix := range runtimeNamespaces {
+		if strings.HasPrefix(function, prefix) {
+			return errclean.FrameRuntime
+		}
+	}
+	return errclean.FrameUser
+}
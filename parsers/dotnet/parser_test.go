@@ -0,0 +1,141 @@
+package dotnet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestDotnetParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Unhandled exception",
+			input: `Unhandled exception. System.NullReferenceException: Object reference not set to an instance of an object.
+   at MyApp.Services.UserService.GetName(Int32 id) in /src/MyApp/Services/UserService.cs:line 42
+   at MyApp.Program.Main(String[] args) in /src/MyApp/Program.cs:line 10`,
+			expectedType:  "System.NullReferenceException",
+			expectedMsg:   "Object reference not set to an instance of an object.",
+			expectedFrame: "/src/MyApp/Services/UserService.cs:42",
+		},
+		{
+			name: "Windows paths",
+			input: `Unhandled exception. System.ArgumentOutOfRangeException: Index was out of range. (Parameter 'index')
+   at System.Collections.Generic.List` + "`" + `1.get_Item(Int32 index)
+   at Billing.Invoice.Total() in C:\src\Billing\Invoice.cs:line 17`,
+			expectedType:  "System.ArgumentOutOfRangeException",
+			expectedMsg:   "Index was out of range.",
+			expectedFrame: `C:\src\Billing\Invoice.cs:17`,
+		},
+		{
+			name: "Compiler error",
+			input: `  Determining projects to restore...
+/src/MyApp/Program.cs(12,5): error CS0103: The name 'foo' does not exist in the current context [/src/MyApp/MyApp.csproj]
+
+Build FAILED.`,
+			expectedType:  "CS0103",
+			expectedMsg:   "The name 'foo' does not exist in the current context",
+			expectedFrame: "/src/MyApp/Program.cs:12:5",
+		},
+		{
+			name:          "NuGet restore error",
+			input:         "/src/MyApp/MyApp.csproj : error NU1101: Unable to find package Foo.Bar. No packages exist with this id in source(s): nuget.org [/src/MyApp/MyApp.csproj]",
+			expectedType:  "NU1101",
+			expectedMsg:   "Unable to find package Foo.Bar.",
+			expectedFrame: "/src/MyApp/MyApp.csproj",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			classifier := errclean.NewClassifier("/src")
+			classifier.ClassifyFrames(result.Stack)
+			if loc := result.PrimaryLocation(); loc.String() != tt.expectedFrame {
+				t.Errorf("PrimaryLocation() = %v, want %v", loc, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestInnerExceptions(t *testing.T) {
+	input := `Unhandled exception. System.InvalidOperationException: Failed to load users
+ ---> System.IO.FileNotFoundException: Could not find file '/data/users.json'.
+File name: '/data/users.json'
+   at Microsoft.Win32.SafeHandles.SafeFileHandle.Open(String fullPath, FileMode mode)
+   at System.IO.File.ReadAllText(String path)
+   at MyApp.UserStore.Read() in /src/MyApp/UserStore.cs:line 15
+   --- End of inner exception stack trace ---
+   at MyApp.UserStore.Load() in /src/MyApp/UserStore.cs:line 19
+   at MyApp.Program.Main() in /src/MyApp/Program.cs:line 8`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 exception, got %d", len(results))
+	}
+
+	result := results[0]
+	if result.Type != "System.InvalidOperationException" || result.Message != "Failed to load users" {
+		t.Errorf("got %s: %s", result.Type, result.Message)
+	}
+	if len(result.Details) != 1 || result.Details[0] != "Inner exception: System.IO.FileNotFoundException: Could not find file '/data/users.json'." {
+		t.Errorf("Details = %q", result.Details)
+	}
+
+	classifier := errclean.NewClassifier("/src")
+	classifier.ClassifyFrames(result.Stack)
+
+	expected := []errclean.FrameKind{errclean.FrameRuntime, errclean.FrameRuntime, errclean.FrameUser, errclean.FrameUser, errclean.FrameUser}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+	if loc := result.PrimaryLocation(); loc.String() != "/src/MyApp/UserStore.cs:15" {
+		t.Errorf("PrimaryLocation() = %v", loc)
+	}
+}
+
+func TestBuildSummaryIsDeduplicated(t *testing.T) {
+	input := `/src/App/Program.cs(3,7): warning CS8618: Non-nullable property 'Name' must contain a non-null value. [/src/App/App.csproj]
+/src/App/Program.cs(12,5): error CS0103: The name 'foo' does not exist in the current context [/src/App/App.csproj]
+
+Build FAILED.
+
+/src/App/Program.cs(3,7): warning CS8618: Non-nullable property 'Name' must contain a non-null value. [/src/App/App.csproj]
+/src/App/Program.cs(12,5): error CS0103: The name 'foo' does not exist in the current context [/src/App/App.csproj]
+    1 Warning(s)
+    1 Error(s)`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(results))
+	}
+	if results[0].Type != "CS0103" || results[1].Type != "CS8618" || results[1].Severity != errclean.SeverityWarning {
+		t.Errorf("got %s, %s (%s)", results[0].Type, results[1].Type, results[1].Severity)
+	}
+}
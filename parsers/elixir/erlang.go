@@ -0,0 +1,239 @@
+package elixir
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Erlang output patterns
+var (
+	// Shell or crash report exception: "** exception error: no match of right hand side value {error,enoent}"
+	erlangExceptionPattern = regexp.MustCompile(`^(?:\*\* )?exception (error|exit|throw): (.*)$`)
+
+	// Frame of an exception: "in function  my_server:init/1 (src/my_server.erl, line 20)"
+	erlangFramePattern = regexp.MustCompile(`^in (?:function|call from)\s+(\S+)(?: \((.+?), line (\d+)\))?$`)
+
+	// Report header: "=CRASH REPORT==== 1-May-2024::10:00:00.000000 ==="
+	reportPattern = regexp.MustCompile(`^=(CRASH|ERROR|SUPERVISOR) REPORT====`)
+
+	// Frame in a stack term: {my_mod,start,1,[{file,"src/my_mod.erl"},{line,12}]}
+	termFramePattern = regexp.MustCompile(`\{('[^']+'|[a-z][\w@]*),('[^']+'|[a-z][\w@]*),(\d+|\[[^\]]*\]),\[(?:\{file,"([^"]+)"\},\{line,(\d+)\})?[^\]]*\]\}`)
+
+	// Report field: "registered_name: my_server", "reason: {badarith,...}"
+	reportFieldPattern = regexp.MustCompile(`^(\w+): (.*)$`)
+
+	// Bare atom: badarg, nonode@nohost
+	atomPattern = regexp.MustCompile(`^[a-z][\w@]*$`)
+)
+
+// Report fields worth keeping as details
+var reportDetails = map[string]bool{
+	"registered_name": true,
+	"supervisor":      true,
+	"errorContext":    true,
+}
+
+// parseReports returns one diagnostic per SASL or logger crash, error or
+// supervisor report
+func parseReports(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	expectTerm := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := reportPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{Type: strings.ToLower(matches[1]) + " report"}
+			results = append(results, current)
+			expectTerm = false
+			continue
+		}
+
+		if current == nil || trimmed == "" {
+			continue
+		}
+
+		// error_logger style: "** Reason for termination ==" followed by "** {badarith,[...]}"
+		body := strings.TrimSpace(strings.TrimPrefix(trimmed, "**"))
+
+		switch {
+		case expectTerm && (strings.HasPrefix(body, "{") || strings.HasPrefix(body, "[") || isAtom(body)):
+			applyTerm(current, body)
+			expectTerm = false
+
+		case strings.HasSuffix(trimmed, "with exit value:"):
+			// "Error in process <0.123.0> on node nonode@nohost with exit value:"
+			expectTerm = true
+
+		case strings.HasPrefix(body, "Reason for termination"):
+			// "** Reason for termination ==", with the term on the same or the next line
+			if rest := strings.TrimLeft(strings.TrimPrefix(body, "Reason for termination"), "= "); rest != "" {
+				applyTerm(current, rest)
+			} else {
+				expectTerm = true
+			}
+
+		case erlangExceptionPattern.MatchString(trimmed):
+			matches := erlangExceptionPattern.FindStringSubmatch(trimmed)
+			current.Type = matches[1]
+			current.Message = cleanMessage(matches[2])
+
+		case erlangFramePattern.MatchString(trimmed):
+			current.Stack = append(current.Stack, parseErlangFrame(erlangFramePattern.FindStringSubmatch(trimmed)))
+
+		case strings.HasPrefix(body, "Generic server "), strings.HasPrefix(body, "Last message in was "):
+			// "** Generic server my_server terminating"; the reason follows
+			current.Details = append(current.Details, cleanMessage(body))
+
+		case reportFieldPattern.MatchString(trimmed):
+			matches := reportFieldPattern.FindStringSubmatch(trimmed)
+			switch field := matches[1]; {
+			case field == "reason":
+				applyTerm(current, matches[2])
+			case reportDetails[field]:
+				current.Details = append(current.Details, matches[1]+": "+cleanMessage(matches[2]))
+			}
+
+		case strings.HasPrefix(trimmed, "initial call: "):
+			current.Details = append(current.Details, cleanMessage(trimmed))
+
+		case current.Message == "" && !strings.HasSuffix(trimmed, ":"):
+			current.Message = cleanMessage(body)
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// applyTerm sets the type, message and stack of a report from an exit
+// reason such as {{badmatch,{error,enoent}},[{my_mod,start,1,[...]}]}
+func applyTerm(result *errclean.CleanedError, term string) {
+	reason, stack := splitReason(term)
+
+	// The reason's tag names the error: badmatch, function_clause, noproc
+	tag := reason
+	if strings.HasPrefix(reason, "{") {
+		tag = firstElement(reason)
+	}
+	if isAtom(tag) {
+		result.Type = strings.Trim(tag, "'")
+	}
+	result.Message = cleanMessage(reason)
+
+	if stack != "" {
+		result.Stack = nil
+		for _, matches := range termFramePattern.FindAllStringSubmatch(stack, -1) {
+			result.Stack = append(result.Stack, parseTermFrame(matches))
+		}
+	}
+}
+
+// splitReason splits an exit reason of the form {Reason, Stacktrace}. It
+// returns the term unchanged if it has no stack trace.
+func splitReason(term string) (reason, stack string) {
+	elements := topLevelElements(term)
+	if len(elements) == 2 && strings.HasPrefix(elements[1], "[{") {
+		return elements[0], elements[1]
+	}
+	return term, ""
+}
+
+// firstElement returns the first element of a tuple
+func firstElement(tuple string) string {
+	if elements := topLevelElements(tuple); len(elements) > 0 {
+		return elements[0]
+	}
+	return ""
+}
+
+// topLevelElements returns the elements of a tuple, or nil if term is not a tuple
+func topLevelElements(term string) []string {
+	term = strings.TrimSpace(term)
+	if !strings.HasPrefix(term, "{") || !strings.HasSuffix(term, "}") {
+		return nil
+	}
+
+	var elements []string
+	depth := 0
+	inString := false
+	start := 1
+	for i := 1; i < len(term)-1; i++ {
+		switch c := term[i]; {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			elements = append(elements, strings.TrimSpace(term[start:i]))
+			start = i + 1
+		}
+	}
+	return append(elements, strings.TrimSpace(term[start:len(term)-1]))
+}
+
+// isAtom reports whether a term is a bare or quoted atom
+func isAtom(term string) bool {
+	if strings.HasPrefix(term, "'") && strings.HasSuffix(term, "'") && len(term) > 1 {
+		return true
+	}
+	return atomPattern.MatchString(term)
+}
+
+// parseErlangFrame builds a frame from the matches of erlangFramePattern
+func parseErlangFrame(matches []string) errclean.Frame {
+	var location errclean.Location
+	text := matches[1]
+	if matches[2] != "" {
+		lineNum, _ := strconv.Atoi(matches[3])
+		location = errclean.Location{File: matches[2], Line: lineNum}
+		text += " (" + location.String() + ")"
+	}
+
+	frame := errclean.NewFrame(text, location)
+	frame.Function = matches[1]
+	frame.Kind = frameKind(matches[1])
+	return frame
+}
+
+// parseTermFrame builds a frame from the matches of termFramePattern
+func parseTermFrame(matches []string) errclean.Frame {
+	function := strings.Trim(matches[1], "'") + ":" + strings.Trim(matches[2], "'")
+	if args := strings.Trim(matches[3], "[]"); strings.HasPrefix(matches[3], "[") {
+		// The arguments instead of the arity, for the frame that failed
+		arity := 0
+		if args != "" {
+			arity = len(topLevelElements("{" + args + "}"))
+		}
+		function += "/" + strconv.Itoa(arity)
+	} else {
+		function += "/" + matches[3]
+	}
+
+	var location errclean.Location
+	text := function
+	if matches[4] != "" {
+		lineNum, _ := strconv.Atoi(matches[5])
+		location = errclean.Location{File: matches[4], Line: lineNum}
+		text += " (" + location.String() + ")"
+	}
+
+	frame := errclean.NewFrame(text, location)
+	frame.Function = function
+	frame.Kind = frameKind(function)
+	return frame
+}
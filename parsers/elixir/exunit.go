@@ -0,0 +1,81 @@
+package elixir
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// ExUnit output patterns
+var (
+	// Failure header: "1) test adds numbers (MyApp.CalcTest)"
+	exunitHeaderPattern = regexp.MustCompile(`^\d+\) ((?:test|doctest|property) .+ \([\w.]+\))$`)
+
+	// Test location below the header: "test/calc_test.exs:5"
+	exunitLocationPattern = regexp.MustCompile(`^(\S+\.exs?):(\d+)$`)
+
+	// Assertion detail: "code:  assert Calc.add(1, 1) == 3", "left:  2", "right: 3"
+	exunitDetailPattern = regexp.MustCompile(`^(code|left|right|arguments|value|match \(=\)|pattern|message|expected|actual):\s+(.*)$`)
+)
+
+// parseExUnit returns one diagnostic per failing ExUnit test, or nil if
+// the output has no failures
+func parseExUnit(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	inStacktrace := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := exunitHeaderPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{Type: "test failure", Test: matches[1]}
+			results = append(results, current)
+			inStacktrace = false
+			continue
+		}
+
+		if current == nil || trimmed == "" {
+			continue
+		}
+
+		// The summary ends the last block: "Finished in 0.03 seconds", "3 tests, 1 failure"
+		if strings.HasPrefix(trimmed, "Finished in ") || strings.HasPrefix(trimmed, "Randomized with seed") {
+			current = nil
+			continue
+		}
+
+		switch {
+		case trimmed == "stacktrace:":
+			inStacktrace = true
+
+		case inStacktrace:
+			if matches := framePattern.FindStringSubmatch(trimmed); matches != nil {
+				current.Stack = append(current.Stack, parseFrame(matches))
+			}
+
+		case current.Location.IsZero() && current.Message == "" && exunitLocationPattern.MatchString(trimmed):
+			current.Location = errclean.ParseLocation(trimmed)
+
+		case exceptionPattern.MatchString(trimmed) && current.Message == "":
+			// An exception raised in the test: "** (ArithmeticError) bad argument"
+			matches := exceptionPattern.FindStringSubmatch(trimmed)
+			current.Type = matches[1]
+			current.Message = cleanMessage(matches[2])
+
+		case current.Message == "":
+			// "Assertion with == failed", "Expected truthy, got false"
+			current.Message = cleanMessage(trimmed)
+
+		case exunitDetailPattern.MatchString(trimmed):
+			matches := exunitDetailPattern.FindStringSubmatch(trimmed)
+			current.Details = append(current.Details, matches[1]+": "+cleanMessage(matches[2]))
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
@@ -0,0 +1,173 @@
+package elixir
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles Elixir exceptions, ExUnit failures and Erlang crash reports
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+// maxTermLength is how much of an embedded Erlang term or Elixir map is kept
+const maxTermLength = 100
+
+var (
+	// Elixir exception: "** (RuntimeError) something went wrong"
+	exceptionPattern = regexp.MustCompile(`^\*\* \(([A-Z][\w.]*)\) ?(.*)$`)
+
+	// Stack frame: "(my_app 0.1.0) lib/my_app/worker.ex:12: MyApp.Worker.run/2"
+	// or, for the test module, "test/calc_test.exs:6: (test)"
+	framePattern = regexp.MustCompile(`^(?:\(([\w]+) ([^)\s]+)\) )?(\S+\.(?:ex|exs|erl|hrl)):(\d+): (.+)$`)
+)
+
+func (p *Parser) Name() string {
+	return "elixir"
+}
+
+func (p *Parser) Detect(text string) int {
+	best := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case exceptionPattern.MatchString(trimmed):
+			return 100
+		case reportPattern.MatchString(trimmed) || erlangExceptionPattern.MatchString(trimmed):
+			return 100
+		case exunitHeaderPattern.MatchString(trimmed):
+			best = max(best, 95)
+		case framePattern.MatchString(trimmed):
+			if matches := framePattern.FindStringSubmatch(trimmed); matches[1] != "" {
+				best = max(best, 85)
+			}
+		}
+	}
+	return best
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per failing test, exception or crash report
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	lines := strings.Split(text, "\n")
+
+	if results := parseExUnit(lines); len(results) > 0 {
+		return results
+	}
+	// Crash reports quote the exception, so they go first
+	if results := parseReports(lines); len(results) > 0 {
+		return results
+	}
+	if results := parseExceptions(lines); len(results) > 0 {
+		return results
+	}
+	return []*errclean.CleanedError{{Type: "error", Message: cleanMessage(strings.TrimSpace(text))}}
+}
+
+// parseExceptions returns one diagnostic per Elixir exception and Erlang
+// shell exception, with its stack trace. Messages can span several
+// paragraphs (FunctionClauseError lists the arguments given), so frames
+// are collected up to the next exception. Lines the logger adds after a
+// GenServer crash, such as "Last message:" and "State:", become details.
+func parseExceptions(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := exceptionPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{Type: matches[1], Message: cleanMessage(matches[2])}
+			results = append(results, current)
+			continue
+		}
+		if matches := erlangExceptionPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{Type: matches[1], Message: cleanMessage(matches[2])}
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch {
+		case framePattern.MatchString(trimmed):
+			current.Stack = append(current.Stack, parseFrame(framePattern.FindStringSubmatch(trimmed)))
+		case erlangFramePattern.MatchString(trimmed):
+			current.Stack = append(current.Stack, parseErlangFrame(erlangFramePattern.FindStringSubmatch(trimmed)))
+		case strings.HasPrefix(trimmed, "Last message") || strings.HasPrefix(trimmed, "State: "):
+			current.Details = append(current.Details, cleanMessage(trimmed))
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// parseFrame builds a frame from the matches of framePattern
+func parseFrame(matches []string) errclean.Frame {
+	lineNum, _ := strconv.Atoi(matches[4])
+	location := errclean.Location{File: matches[3], Line: lineNum}
+
+	text := location.String() + ": " + matches[5]
+	if matches[1] != "" {
+		text = "(" + matches[1] + " " + matches[2] + ") " + text
+	}
+	frame := errclean.NewFrame(text, location)
+	if matches[5] != "(test)" {
+		frame.Function = matches[5]
+		frame.Kind = frameKind(matches[5])
+	}
+	return frame
+}
+
+// Function name prefixes of the Elixir standard library and OTP. Elixir
+// frames name Erlang modules as :gen_server, Erlang frames as gen_server:.
+var runtimeModules = []string{
+	":", "Enum.", "Kernel.", "GenServer.", "Task.", "Stream.", "Process.", "Agent.", "Supervisor.",
+	"ExUnit.", "Mix.", "IEx.", "Code.",
+	"erlang:", "gen_server:", "gen_statem:", "gen_event:", "gen:", "proc_lib:", "supervisor:",
+	"erl_eval:", "shell:", "lists:", "maps:", "io:", "file:", "ets:", "application_master:",
+}
+
+// Function name prefixes of widely used Elixir libraries. Frames name the
+// source file relative to the library, so the module decides.
+var dependencyModules = []string{
+	"Plug.", "Phoenix.", "Ecto.", "DBConnection.", "Postgrex.", "Jason.", "Bandit.",
+}
+
+// frameKind returns where the code of a function comes from. The parser
+// decides, not the classifier, because the same module names are user
+// code in other languages.
+func frameKind(function string) errclean.FrameKind {
+	for _, prefix := range runtimeModules {
+		if strings.HasPrefix(function, prefix) {
+			return errclean.FrameRuntime
+		}
+	}
+	for _, prefix := range dependencyModules {
+		if strings.HasPrefix(function, prefix) {
+			return errclean.FrameDependency
+		}
+	}
+	return errclean.FrameUser
+}
+
+// cleanMessage strips noise from a message and shortens the terms in it
+func cleanMessage(text string) string {
+	return errclean.TruncateTerms(errclean.StripNoise(text), maxTermLength)
+}
@@ -0,0 +1,274 @@
+package elixir
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestElixirParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Elixir exception",
+			input: `** (RuntimeError) something went wrong
+    (my_app 0.1.0) lib/my_app/worker.ex:12: MyApp.Worker.run/2
+    (elixir 1.15.7) lib/enum.ex:975: Enum."-each/2-lists^foreach/1-0-"/2
+    (stdlib 5.1) erl_eval.erl:750: :erl_eval.do_apply/7`,
+			expectedType:  "RuntimeError",
+			expectedMsg:   "something went wrong",
+			expectedFrame: "lib/my_app/worker.ex:12",
+		},
+		{
+			name: "GenServer crash",
+			input: `12:00:00.000 [error] GenServer MyApp.Cache terminating
+** (KeyError) key :user not found in: %{session: "abc", settings: %{theme: "dark", language: "en", notifications: true, timezone: "UTC"}}
+    (my_app 0.1.0) lib/my_app/cache.ex:30: MyApp.Cache.handle_call/3
+    (stdlib 5.1) gen_server.erl:1113: :gen_server.try_handle_call/4
+Last message (from #PID<0.150.0>): {:get, :user}
+State: %{}`,
+			expectedType:  "KeyError",
+			expectedMsg:   "key :user not found in: %{session: \"abc\", settings: %{theme: \"dark\"",
+			expectedFrame: "lib/my_app/cache.ex:30",
+		},
+		{
+			name: "Erlang shell exception",
+			input: `** exception error: no match of right hand side value {error,enoent}
+     in function  my_mod:read_config/1 (src/my_mod.erl, line 12)
+     in call from erl_eval:do_apply/7 (erl_eval.erl, line 750)`,
+			expectedType:  "error",
+			expectedMsg:   "no match of right hand side value {error,enoent}",
+			expectedFrame: "src/my_mod.erl:12",
+		},
+		{
+			name: "ExUnit assertion",
+			input: `  1) test adds numbers (MyApp.CalcTest)
+     test/calc_test.exs:5
+     Assertion with == failed
+     code:  assert Calc.add(1, 1) == 3
+     left:  2
+     right: 3
+     stacktrace:
+       test/calc_test.exs:6: (test)
+
+Finished in 0.03 seconds (0.00s async, 0.03s sync)
+1 test, 1 failure`,
+			expectedType:  "test failure",
+			expectedMsg:   "Assertion with == failed",
+			expectedFrame: "test/calc_test.exs:5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+			if loc := result.PrimaryLocation(); loc.String() != tt.expectedFrame {
+				t.Errorf("PrimaryLocation() = %v, want %v", loc, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestGenServerCrashIsCleaned(t *testing.T) {
+	input := `** (KeyError) key :user not found in: %{session: "abc", settings: %{theme: "dark", language: "en", notifications: true, timezone: "UTC", beta: false}, cart: [1, 2, 3]}
+    (my_app 0.1.0) lib/my_app/cache.ex:30: MyApp.Cache.handle_call/3
+    (stdlib 5.1) gen_server.erl:1113: :gen_server.try_handle_call/4
+    (plug 1.14.0) lib/plug/conn.ex:400: Plug.Conn.run_before_send/2
+Last message (from #PID<0.150.0>): {:get, :user}`
+
+	result := (&Parser{}).Parse(input)
+	if !strings.HasSuffix(result.Message, "…}}") || len(result.Message) > 140 {
+		t.Errorf("Message not truncated: %q", result.Message)
+	}
+	if len(result.Details) != 1 || result.Details[0] != "Last message (from [PID]): {:get, :user}" {
+		t.Errorf("Details = %q", result.Details)
+	}
+
+	errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+	expected := []errclean.FrameKind{errclean.FrameUser, errclean.FrameRuntime, errclean.FrameDependency}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+}
+
+func TestExUnitException(t *testing.T) {
+	input := `  1) test divides (MyApp.CalcTest)
+     test/calc_test.exs:10
+     ** (ArithmeticError) bad argument in arithmetic expression
+     code: Calc.div(1, 0)
+     stacktrace:
+       (my_app 0.1.0) lib/calc.ex:8: MyApp.Calc.div/2
+       test/calc_test.exs:11: (test)
+
+  2) doctest MyApp.Calc.add/2 (1) (MyApp.CalcTest)
+     test/calc_test.exs:3
+     Doctest failed
+     doctest:
+       iex> MyApp.Calc.add(1, 2)
+       4
+     code:  MyApp.Calc.add(1, 2) === 4
+     left:  3
+     right: 4
+     stacktrace:
+       lib/calc.ex:4: MyApp.Calc (module)`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 failures, got %d", len(results))
+	}
+
+	first := results[0]
+	if first.Type != "ArithmeticError" || first.Test != "test divides (MyApp.CalcTest)" {
+		t.Errorf("first = %s / %s", first.Type, first.Test)
+	}
+	if len(first.Stack) != 2 || first.Stack[1].Function != "" {
+		t.Errorf("first stack = %v", first.Stack)
+	}
+
+	second := results[1]
+	if second.Message != "Doctest failed" || len(second.Details) != 3 || second.Details[1] != "left: 3" {
+		t.Errorf("second = %q, details %q", second.Message, second.Details)
+	}
+}
+
+func TestCrashReports(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Error report with exit value",
+			input: `=ERROR REPORT==== 1-May-2024::10:00:00.000000 ===
+Error in process <0.123.0> on node nonode@nohost with exit value:
+{{badmatch,{error,enoent}},[{my_mod,start,1,[{file,"src/my_mod.erl"},{line,12}]},{erl_eval,do_apply,7,[{file,"erl_eval.erl"},{line,750}]}]}`,
+			expectedType:  "badmatch",
+			expectedMsg:   "{badmatch,{error,enoent}}",
+			expectedFrame: "src/my_mod.erl:12",
+		},
+		{
+			name: "Crash report",
+			input: `=CRASH REPORT==== 1-May-2024::10:00:00.000000 ===
+  crasher:
+    initial call: my_server:init/1
+    pid: <0.130.0>
+    registered_name: my_server
+    exception exit: {noproc,{gen_server,call,[db,get]}}
+      in function  gen_server:call/2 (gen_server.erl, line 385)
+      in call from my_server:init/1 (src/my_server.erl, line 20)
+    ancestors: [my_sup,<0.120.0>]`,
+			expectedType:  "exit",
+			expectedMsg:   "{noproc,{gen_server,call,[db,get]}}",
+			expectedFrame: "src/my_server.erl:20",
+		},
+		{
+			name: "Generic server termination",
+			input: `=ERROR REPORT==== 1-May-2024::10:00:00 ===
+** Generic server my_server terminating
+** Last message in was crash
+** When Server state == []
+** Reason for termination ==
+** {badarith,[{my_server,handle_call,3,[{file,"src/my_server.erl"},{line,30}]},{gen_server,try_handle_call,4,[{file,"gen_server.erl"},{line,1113}]}]}`,
+			expectedType:  "badarith",
+			expectedMsg:   "badarith",
+			expectedFrame: "src/my_server.erl:30",
+		},
+		{
+			name: "Supervisor report",
+			input: `=SUPERVISOR REPORT==== 1-May-2024::10:00:00 ===
+    supervisor: {local,my_sup}
+    errorContext: start_error
+    reason: {undef,[{my_worker,start_link,[],[]},{supervisor,do_start_child_i,3,[{file,"supervisor.erl"},{line,420}]}]}
+    offender: [{pid,undefined},{id,my_worker}]`,
+			expectedType:  "undef",
+			expectedMsg:   "undef",
+			expectedFrame: "",
+		},
+	}
+
+	parser := &Parser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence != 100 {
+				t.Errorf("Detect() = %d, want 100", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+			if result.Message != tt.expectedMsg {
+				t.Errorf("Message = %q, want %q", result.Message, tt.expectedMsg)
+			}
+
+			errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+			if loc := result.PrimaryLocation(); loc.String() != tt.expectedFrame {
+				t.Errorf("PrimaryLocation() = %v, want %v (stack %v)", loc, tt.expectedFrame, result.Stack)
+			}
+		})
+	}
+}
+
+func TestReportStackFromTerm(t *testing.T) {
+	input := `=SUPERVISOR REPORT==== 1-May-2024::10:00:00 ===
+    reason: {undef,[{my_worker,start_link,[],[]},{supervisor,do_start_child_i,3,[{file,"supervisor.erl"},{line,420}]}]}`
+
+	result := (&Parser{}).Parse(input)
+	if len(result.Stack) != 2 {
+		t.Fatalf("expected 2 frames, got %v", result.Stack)
+	}
+	if result.Stack[0].Function != "my_worker:start_link/0" || result.Stack[1].Location.String() != "supervisor.erl:420" {
+		t.Errorf("Stack = %v", result.Stack)
+	}
+}
+
+func TestErlangFrameKinds(t *testing.T) {
+	input := `** exception error: bad argument
+     in function  erlang:binary_to_atom/2
+        called as binary_to_atom(42,utf8)
+     in call from my_mod:convert/1 (src/my_mod.erl, line 8)
+     in call from erl_eval:do_apply/7 (erl_eval.erl, line 750)`
+
+	result := (&Parser{}).Parse(input)
+	errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+
+	expected := []errclean.FrameKind{errclean.FrameRuntime, errclean.FrameUser, errclean.FrameRuntime}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+}
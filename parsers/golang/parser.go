@@ -26,7 +26,7 @@ func (p *Parser) Detect(text string) int {
 		line = strings.TrimSpace(line)
 
 		// Go build errors: definitive
-		if regexp.MustCompile(`^\./?[\w/]+\.go:\d+:\d+:`).MatchString(line) {
+		if buildErrorPattern.MatchString(line) {
 			return 100
 		}
 
@@ -60,33 +60,60 @@ func (p *Parser) Detect(text string) int {
 }
 
 func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per build error, or the single panic,
+// fatal error or test failure found in the text
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
 	lines := strings.Split(text, "\n")
-	result := &errclean.CleanedError{}
 
-	var stackFrames []string
+	if results := parseBuildErrors(lines); len(results) > 0 {
+		return results
+	}
 
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
+	return []*errclean.CleanedError{parseLines(lines)}
+}
 
-		// Build errors: "./main.go:15:2: undefined: fmt.Printl"
-		if regexp.MustCompile(`^\./?[\w/]+\.go:\d+:\d+:`).MatchString(trimmed) {
-			// Only set the message if this is the first error
-			if result.Type != "build error" {
-				result.Type = "build error"
-				// Extract the error message
-				parts := strings.SplitN(trimmed, ": ", 2)
-				if len(parts) >= 2 {
-					result.Message = parts[1]
-				}
-			}
-			// Keep all file locations
-			parts := strings.SplitN(trimmed, ": ", 2)
-			if len(parts) >= 1 {
-				stackFrames = append(stackFrames, errclean.StripNoise(parts[0]))
-			}
+// parseBuildErrors handles compiler and vet output:
+// "./main.go:15:2: undefined: fmt.Printl"
+func parseBuildErrors(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !buildErrorPattern.MatchString(trimmed) {
 			continue
 		}
 
+		parts := strings.SplitN(trimmed, ": ", 2)
+		location := errclean.ParseLocation(parts[0])
+		result := &errclean.CleanedError{
+			Type:     "build error",
+			Location: location,
+			Stack:    []errclean.Frame{errclean.NewFrame(parts[0], location)},
+		}
+		if len(parts) >= 2 {
+			result.Message = errclean.StripNoise(parts[1])
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// Build error location: "./main.go:15:2:"
+var buildErrorPattern = regexp.MustCompile(`^\./?[\w/]+\.go:\d+:\d+:`)
+
+// parseLines handles panics, fatal errors and test failures
+func parseLines(lines []string) *errclean.CleanedError {
+	result := &errclean.CleanedError{}
+
+	var stackFrames []errclean.Frame
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
 		// Test failures: "--- FAIL: TestName (0.00s)"
 		if strings.HasPrefix(trimmed, "--- FAIL:") {
 			result.Type = "test failure"
@@ -102,7 +129,7 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 				if result.Message == "" {
 					result.Message = parts[1]
 				}
-				stackFrames = append(stackFrames, errclean.StripNoise(parts[0]))
+				stackFrames = append(stackFrames, errclean.NewFrame(parts[0], errclean.ParseLocation(parts[0])))
 			}
 			continue
 		}
@@ -125,21 +152,30 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 		// functionName(args)
 		//     /path/to/file.go:42 +0x123
 		if i > 0 && strings.Contains(line, ".go:") {
-			// This is the file:line part
-			frame := errclean.StripNoise(trimmed)
+			// This is the file:line part, without the PC offset
+			location := errclean.ParseLocation(strings.Fields(trimmed)[0])
 
 			// Get the function name from previous line
 			prevLine := strings.TrimSpace(lines[i-1])
 			if prevLine != "" && !strings.HasPrefix(prevLine, "goroutine") &&
 				!strings.HasPrefix(prevLine, "panic:") && !strings.HasPrefix(prevLine, "fatal error:") {
-				// Combine function and location
-				funcName := strings.Split(prevLine, "(")[0]
-				stackFrames = append(stackFrames, funcName+" "+frame)
+				// Combine function and location, dropping the arguments:
+				// "main.(*Server).handle(0xc000010000)" -> "main.(*Server).handle"
+				funcName := prevLine
+				if paren := strings.LastIndex(prevLine, "("); paren > 0 && strings.HasSuffix(prevLine, ")") {
+					funcName = prevLine[:paren]
+				}
+				frame := errclean.NewFrame(funcName+" "+trimmed, location)
+				frame.Function = funcName
+				stackFrames = append(stackFrames, frame)
 			}
 		}
 	}
 
 	result.Stack = errclean.DeduplicateFrames(stackFrames)
+	if len(result.Stack) > 0 {
+		result.Location = result.Stack[0].Location
+	}
 	result.Message = errclean.StripNoise(result.Message)
 	return result
 }
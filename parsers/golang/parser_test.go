@@ -0,0 +1,86 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Panic",
+			input: `panic: runtime error: invalid memory address or nil pointer dereference
+
+goroutine 1 [running]:
+main.(*Server).handle(0xc00012e000)
+	/home/dev/app/server.go:42 +0x123
+main.main()
+	/home/dev/app/main.go:10 +0x45`,
+			expectedType:  "panic",
+			expectedMsg:   "invalid memory address",
+			expectedFrame: "main.(*Server).handle server.go:42",
+		},
+		{
+			name: "Test failure",
+			input: `--- FAIL: TestCalculate (0.00s)
+    calculator_test.go:25: Expected 10, got 5`,
+			expectedType:  "test failure",
+			expectedMsg:   "Expected 10, got 5",
+			expectedFrame: "calculator_test.go:25",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			if len(result.Stack) == 0 || !strings.Contains(result.Stack[0].Text, tt.expectedFrame) {
+				t.Errorf("Stack = %v, want first frame containing %q", result.Stack, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestGoBuildErrors(t *testing.T) {
+	parser := &Parser{}
+
+	input := `# github.com/user/myapp
+./main.go:15:2: undefined: fmt.Printl
+./utils.go:42:9: syntax error: unexpected newline, expecting comma or }`
+
+	results := parser.ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	expected := []struct {
+		location string
+		message  string
+	}{
+		{"./main.go:15:2", "undefined: fmt.Printl"},
+		{"./utils.go:42:9", "syntax error: unexpected newline, expecting comma or }"},
+	}
+
+	for i, want := range expected {
+		if results[i].Location.String() != want.location || results[i].Message != want.message {
+			t.Errorf("results[%d] = %v: %v, want %v: %v", i,
+				results[i].Location, results[i].Message, want.location, want.message)
+		}
+	}
+}
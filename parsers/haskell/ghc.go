@@ -0,0 +1,150 @@
+package haskell
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// GHC diagnostic patterns
+var (
+	// Diagnostic header: "src/Foo.hs:12:5: error: [GHC-83865]", "src/Foo.hs:(12,5)-(14,10): warning: [-Wunused-imports]",
+	// with the message inline, "src/Foo.hs:3:1: error: parse error on input ‘where’", or from GHC
+	// before 8.0, which gave no level for errors, "src/Foo.hs:12:5:" and "src/Foo.hs:12:5: Warning:"
+	headerPattern = regexp.MustCompile(`^(\S+\.(?:hs|lhs|hsc|hs-boot)):(?:(\d+):(\d+)(?:-\d+)?|\((\d+),(\d+)\)-\(\d+,\d+\)):(?: (error|[Ww]arning):?)?\s*(.*)$`)
+
+	// Error code or warning flag after the level: "[GHC-83865]", "[-Wunused-imports, Werror=unused-imports]"
+	tagPattern = regexp.MustCompile(`^\[(GHC-\d+|-W[\w-]+|-Werror=[\w-]+)(?:, [^\]]*)?\]\s*`)
+
+	// Source excerpt below the message: "   |", "12 |     f x", "   |       ^^^"
+	excerptPattern = regexp.MustCompile(`^\d*\s*\|`)
+)
+
+// severities maps GHC levels to diagnostic severities
+var severities = map[string]errclean.Severity{
+	"error":   errclean.SeverityError,
+	"warning": errclean.SeverityWarning,
+}
+
+// parseDiagnostics returns one diagnostic per GHC error or warning, or nil
+// if the output has none. GHC explains an error in bullets; the first one
+// is the message, and of the rest only suggestions are kept: "In the
+// expression ..." context repeats what the location already says.
+func parseDiagnostics(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	var bullets []string
+	indent := 0
+
+	finish := func() {
+		if current != nil {
+			applyBullets(current, bullets)
+		}
+		current, bullets = nil, nil
+	}
+
+	for _, line := range lines {
+		if matches := headerPattern.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+			finish()
+			current = newDiagnostic(matches)
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		// The body is indented; anything else ends it
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			finish()
+			continue
+		}
+		if excerptPattern.MatchString(trimmed) {
+			continue
+		}
+
+		// An entry continues on the more indented lines below it. Not every
+		// message has bullets: "Variable not in scope" and GHC before 8.0.
+		lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if text, ok := strings.CutPrefix(trimmed, "•"); ok {
+			bullets = append(bullets, strings.TrimSpace(text))
+			indent = lineIndent
+		} else if len(bullets) > 0 && lineIndent > indent {
+			bullets[len(bullets)-1] += "\n" + trimmed
+		} else {
+			bullets = append(bullets, trimmed)
+			indent = lineIndent
+		}
+	}
+	finish()
+
+	errclean.SortBySeverity(results)
+	return results
+}
+
+// newDiagnostic builds a diagnostic from the matches of headerPattern
+func newDiagnostic(matches []string) *errclean.CleanedError {
+	line, column := matches[2], matches[3]
+	if line == "" {
+		line, column = matches[4], matches[5]
+	}
+	lineNum, _ := strconv.Atoi(line)
+	columnNum, _ := strconv.Atoi(column)
+	location := errclean.Location{File: matches[1], Line: lineNum, Column: columnNum}
+
+	level := strings.ToLower(matches[6])
+	if level == "" {
+		level = "error"
+	}
+	result := &errclean.CleanedError{
+		Type:     level,
+		Severity: severities[level],
+		Location: location,
+		Stack:    []errclean.Frame{errclean.NewFrame(location.String(), location)},
+	}
+
+	// Report warnings by flag and errors by code: -Wunused-imports, GHC-83865
+	var code, flag string
+	rest := matches[7]
+	for {
+		tag := tagPattern.FindStringSubmatch(rest)
+		if tag == nil {
+			break
+		}
+		if strings.HasPrefix(tag[1], "GHC-") {
+			code = tag[1]
+		} else if flag == "" {
+			flag = tag[1]
+		}
+		rest = rest[len(tag[0]):]
+	}
+	switch {
+	case level == "warning" && flag != "":
+		result.Type = flag
+	case code != "":
+		result.Type = code
+	}
+	result.Message = errclean.StripNoise(strings.TrimSpace(rest))
+	return result
+}
+
+// applyBullets sets the message from the first bullet and keeps the
+// suggestions among the others
+func applyBullets(result *errclean.CleanedError, bullets []string) {
+	for i, bullet := range bullets {
+		text := strings.Join(strings.Fields(bullet), " ")
+		switch {
+		case i == 0 && result.Message == "":
+			result.Message = errclean.StripNoise(text)
+		case strings.HasPrefix(text, "Perhaps "), strings.HasPrefix(text, "Suggested fix"), strings.HasPrefix(text, "Probable fix"):
+			result.Details = append(result.Details, errclean.StripNoise(text))
+		case i == 0:
+			// The message was inline; the first bullet adds to it
+			result.Details = append(result.Details, errclean.StripNoise(text))
+		}
+	}
+}
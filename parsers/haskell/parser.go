@@ -0,0 +1,182 @@
+package haskell
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles GHC diagnostics, Cabal and Stack build output and runtime exceptions
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+var (
+	// Stack prefixes the output of each package it builds: "myapp    > src/Foo.hs:12:5: error:"
+	packagePrefixPattern = regexp.MustCompile(`^[\w.-]+\s*> ?`)
+
+	// Uncaught exception in GHCi or a program: "*** Exception: Prelude.head: empty list"
+	exceptionPattern = regexp.MustCompile(`^\*\*\* Exception: (.*)$`)
+
+	// HasCallStack frame: "head, called at src/Foo.hs:10:14 in main:Foo"
+	callStackPattern = regexp.MustCompile(`^(\S+), called at (\S+?):(\d+):(\d+) in ([\w.-]+):([\w.']+)$`)
+
+	// Build tool failure: "Error: [S-7282]", "Error: cabal: Failed to build myapp-0.1.0.0."
+	buildErrorPattern = regexp.MustCompile(`^(?:Error: (?:\[((?:S|Cabal)-\d+)\])?\s*(?:cabal: )?|cabal: )(.*)$`)
+)
+
+func (p *Parser) Name() string {
+	return "haskell"
+}
+
+func (p *Parser) Detect(text string) int {
+	best := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(stripPackagePrefix(line))
+
+		switch {
+		case headerPattern.MatchString(trimmed):
+			return 100
+		case exceptionPattern.MatchString(trimmed):
+			return 100
+		case strings.HasPrefix(trimmed, "CallStack (from HasCallStack):"), callStackPattern.MatchString(trimmed):
+			best = max(best, 95)
+		case strings.HasPrefix(trimmed, "Error: [S-"), strings.HasPrefix(trimmed, "Error: [Cabal-"), strings.HasPrefix(trimmed, "Error: cabal: "):
+			best = max(best, 90)
+		case strings.HasPrefix(trimmed, "cabal: "):
+			best = max(best, 70)
+		}
+	}
+	return best
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per GHC error or warning or per runtime
+// exception, falling back to the build tool's summary of the failure
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = stripPackagePrefix(line)
+	}
+
+	if results := parseDiagnostics(lines); len(results) > 0 {
+		return results
+	}
+	if results := parseExceptions(lines); len(results) > 0 {
+		return results
+	}
+	if result := parseBuildFailure(lines); result != nil {
+		return []*errclean.CleanedError{result}
+	}
+	return []*errclean.CleanedError{{Type: "error", Message: errclean.StripNoise(strings.TrimSpace(text))}}
+}
+
+// stripPackagePrefix removes the package name Stack puts before each line
+// of a build. Paths never contain "> ", so compiler output is unaffected.
+func stripPackagePrefix(line string) string {
+	if !strings.Contains(line, "> ") && !strings.HasSuffix(line, ">") {
+		return line
+	}
+	return packagePrefixPattern.ReplaceAllString(line, "")
+}
+
+// parseExceptions returns one diagnostic per uncaught exception, with its
+// HasCallStack frames. Programs print "prog: message" rather than
+// "*** Exception:", which is only recognized with a call stack below it.
+func parseExceptions(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := exceptionPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{Type: "Exception", Message: errclean.StripNoise(matches[1])}
+			results = append(results, current)
+			continue
+		}
+
+		if trimmed == "CallStack (from HasCallStack):" || trimmed == "HasCallStack backtrace:" {
+			if current == nil && i > 0 {
+				// "myapp: Prelude.head: empty list" on the line above
+				message := strings.TrimSpace(lines[i-1])
+				if prog, rest, ok := strings.Cut(message, ": "); ok && !strings.ContainsAny(prog, " .") {
+					message = rest
+				}
+				current = &errclean.CleanedError{Type: "Exception", Message: errclean.StripNoise(message)}
+				results = append(results, current)
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if matches := callStackPattern.FindStringSubmatch(trimmed); matches != nil {
+			current.Stack = append(current.Stack, parseFrame(trimmed, matches))
+			continue
+		}
+		if trimmed == "" || !strings.HasPrefix(line, " ") {
+			current = nil
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// parseFrame builds a frame from a line matching callStackPattern. The
+// frame names the function called, so the code at the location belongs
+// to the package and module after "in": "base:GHC.List", "main:Foo".
+func parseFrame(line string, matches []string) errclean.Frame {
+	lineNum, _ := strconv.Atoi(matches[3])
+	column, _ := strconv.Atoi(matches[4])
+	location := errclean.Location{File: matches[2], Line: lineNum, Column: column}
+
+	frame := errclean.NewFrame(line, location)
+	frame.Function = matches[5] + ":" + matches[6]
+	return frame
+}
+
+// parseBuildFailure returns the Stack or Cabal summary of a failed build,
+// or nil if the output has none
+func parseBuildFailure(lines []string) *errclean.CleanedError {
+	for i, line := range lines {
+		matches := buildErrorPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+
+		result := &errclean.CleanedError{Type: "build failed", Message: errclean.StripNoise(matches[2])}
+		if matches[1] != "" {
+			result.Type = matches[1]
+		}
+
+		// "Error: [S-7282]" puts the message on the indented lines below
+		for _, next := range lines[i+1:] {
+			next = strings.TrimSpace(next)
+			if next == "" {
+				break
+			}
+			if result.Message == "" {
+				result.Message = errclean.StripNoise(next)
+			} else {
+				result.Details = append(result.Details, errclean.StripNoise(next))
+			}
+		}
+		return result
+	}
+	return nil
+}
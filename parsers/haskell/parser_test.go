@@ -0,0 +1,233 @@
+package haskell
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestHaskellParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Type error with code",
+			input: `src/Foo.hs:12:5: error: [GHC-83865]
+    • Couldn't match expected type: Int
+                  with actual type: [Char]
+    • In the first argument of ‘f’, namely ‘x’
+      In the expression: f x
+      In an equation for ‘g’: g x = f x
+   |
+12 |     f x
+   |       ^`,
+			expectedType:  "GHC-83865",
+			expectedMsg:   "Couldn't match expected type: Int with actual type: [Char]",
+			expectedFrame: "src/Foo.hs:12:5",
+		},
+		{
+			name: "Type error before error codes",
+			input: `src/Foo.hs:12:5: error:
+    • Couldn't match expected type ‘Int’ with actual type ‘[Char]’
+    • In the first argument of ‘f’, namely ‘x’`,
+			expectedType:  "error",
+			expectedMsg:   "Couldn't match expected type ‘Int’ with actual type ‘[Char]’",
+			expectedFrame: "src/Foo.hs:12:5",
+		},
+		{
+			name: "Parse error inline",
+			input: `app/Main.hs:3:1: error: [GHC-58481]
+    parse error on input ‘where’
+  |
+3 | where
+  | ^^^^^`,
+			expectedType:  "GHC-58481",
+			expectedMsg:   "parse error on input ‘where’",
+			expectedFrame: "app/Main.hs:3:1",
+		},
+		{
+			name: "Stack build",
+			input: `myapp    > build (lib + exe)
+myapp    > [2 of 3] Compiling Foo
+myapp    > /home/dev/myapp/src/Foo.hs:(20,1)-(22,15): error: [GHC-62161]
+myapp    >     Pattern match(es) are non-exhaustive
+myapp    >
+Error: [S-7282]
+       Stack failed to execute the build plan.`,
+			expectedType:  "GHC-62161",
+			expectedMsg:   "Pattern match(es) are non-exhaustive",
+			expectedFrame: "/home/dev/myapp/src/Foo.hs:20:1",
+		},
+		{
+			name: "GHCi exception",
+			input: `ghci> head []
+*** Exception: Prelude.head: empty list
+CallStack (from HasCallStack):
+  error, called at libraries/base/GHC/List.hs:1646:3 in base:GHC.List
+  errorEmptyList, called at libraries/base/GHC/List.hs:85:11 in base:GHC.List
+  head, called at src/Foo.hs:10:14 in main:Foo`,
+			expectedType:  "Exception",
+			expectedMsg:   "Prelude.head: empty list",
+			expectedFrame: "src/Foo.hs:10:14",
+		},
+		{
+			name: "Program exception",
+			input: `myapp: config file is missing
+CallStack (from HasCallStack):
+  error, called at src/Config.hs:30:7 in myapp-0.1.0-inplace:Config`,
+			expectedType:  "Exception",
+			expectedMsg:   "config file is missing",
+			expectedFrame: "src/Config.hs:30:7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+			if loc := result.PrimaryLocation(); loc.String() != tt.expectedFrame {
+				t.Errorf("PrimaryLocation() = %v, want %v", loc, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestContextIsDropped(t *testing.T) {
+	input := `src/Foo.hs:8:9: error: [GHC-88464]
+    Variable not in scope: lenght :: [a0] -> Int
+    Suggested fix:
+      Perhaps use ‘length’ (imported from Prelude)
+  |
+8 |   print (lenght xs)
+  |          ^^^^^^
+
+src/Foo.hs:12:5: error: [GHC-39999]
+    • No instance for ‘Show Widget’ arising from a use of ‘print’
+    • In a stmt of a 'do' block: print w
+      In the expression: do print w
+    • Relevant bindings include w :: Widget (bound at src/Foo.hs:11:3)`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(results))
+	}
+
+	first := results[0]
+	if first.Message != "Variable not in scope: lenght :: [a0] -> Int" {
+		t.Errorf("Message = %q", first.Message)
+	}
+	if len(first.Details) != 1 || first.Details[0] != "Suggested fix: Perhaps use ‘length’ (imported from Prelude)" {
+		t.Errorf("Details = %q", first.Details)
+	}
+
+	second := results[1]
+	if second.Message != "No instance for ‘Show Widget’ arising from a use of ‘print’" || len(second.Details) != 0 {
+		t.Errorf("second = %q, details %q", second.Message, second.Details)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	input := `src/Foo.hs:3:1: warning: [GHC-66111] [-Wunused-imports]
+    The import of ‘Data.List’ is redundant
+      except perhaps to import instances from ‘Data.List’
+    To import instances alone, use: import Data.List()
+src/Foo.hs:9:1: error: [GHC-76037]
+    Not in scope: type constructor or class ‘Widgt’
+src/Bar.hs:5:1: Warning:
+    Top-level binding with no type signature: main :: IO ()`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d", len(results))
+	}
+
+	if results[0].Type != "GHC-76037" || results[0].Severity != errclean.SeverityError {
+		t.Errorf("errors should sort first, got %s", results[0].Type)
+	}
+	if results[1].Type != "-Wunused-imports" || results[1].Severity != errclean.SeverityWarning {
+		t.Errorf("warning = %s (%s)", results[1].Type, results[1].Severity)
+	}
+	if results[1].Message != "The import of ‘Data.List’ is redundant except perhaps to import instances from ‘Data.List’" {
+		t.Errorf("Message = %q", results[1].Message)
+	}
+	if results[2].Type != "warning" || results[2].Location.String() != "src/Bar.hs:5:1" {
+		t.Errorf("old-style warning = %s at %s", results[2].Type, results[2].Location)
+	}
+}
+
+func TestBuildFailure(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectedType string
+		expectedMsg  string
+	}{
+		{
+			name: "Stack",
+			input: `Error: [S-7282]
+       Stack failed to execute the build plan.`,
+			expectedType: "S-7282",
+			expectedMsg:  "Stack failed to execute the build plan.",
+		},
+		{
+			name:         "Cabal",
+			input:        `Error: cabal: Failed to build myapp-0.1.0.0.`,
+			expectedType: "build failed",
+			expectedMsg:  "Failed to build myapp-0.1.0.0.",
+		},
+	}
+
+	parser := &Parser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+			if result.Type != tt.expectedType || result.Message != tt.expectedMsg {
+				t.Errorf("got %s: %q, want %s: %q", result.Type, result.Message, tt.expectedType, tt.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestBaseFramesAreRuntime(t *testing.T) {
+	input := `*** Exception: Prelude.head: empty list
+CallStack (from HasCallStack):
+  error, called at libraries/base/GHC/List.hs:1646:3 in base:GHC.List
+  head, called at src/Foo.hs:10:14 in main:Foo`
+
+	result := (&Parser{}).Parse(input)
+	errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+
+	expected := []errclean.FrameKind{errclean.FrameRuntime, errclean.FrameUser}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+}
@@ -33,8 +33,18 @@ func (p *Parser) Detect(text string) int {
 			return 100
 		}
 
+		// Jest/Vitest failing suite: high confidence
+		if failHeaderPattern.MatchString(line) {
+			return 95
+		}
+
+		// Mocha failure summary: high confidence
+		if mochaFailingPattern.MatchString(line) {
+			return 90
+		}
+
 		// TypeScript compile errors: high confidence
-		if strings.Contains(line, " - error TS") {
+		if tsPrettyPattern.MatchString(line) || tsPlainPattern.MatchString(line) {
 			return 100
 		}
 
@@ -59,32 +69,47 @@ func (p *Parser) Detect(text string) int {
 
 // Parse processes JavaScript/TypeScript error text
 func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per failing test and TypeScript error,
+// followed by any npm error, or the single JavaScript error found in the text
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	return p.ParseAllIn(text, "")
+}
+
+// ParseAllIn is ParseAll with the bundles and source maps of relative
+// stack frames read from the project root
+func (p *Parser) ParseAllIn(text, root string) []*errclean.CleanedError {
 	lines := strings.Split(text, "\n")
-	result := &errclean.CleanedError{}
 
-	var stackFrames []string
-	foundError := false
+	// Test failures and compile errors rank above the npm lifecycle error
+	// that wraps them
+	maps := newSourceMapResolver(root)
+	results := parseTestFailures(lines, maps)
+	results = append(results, parseTypeScript(lines)...)
 
-	// Check for npm errors first
 	if strings.Contains(text, "npm ERR!") {
-		return parseNpmError(lines)
+		results = append(results, parseNpmError(lines))
+	}
+
+	if len(results) > 0 {
+		return results
 	}
 
+	return []*errclean.CleanedError{parseError(lines, maps)}
+}
+
+// parseError handles standard errors and promise rejections
+func parseError(lines []string, maps *sourceMapResolver) *errclean.CleanedError {
+	result := &errclean.CleanedError{}
+
+	var stackFrames []errclean.Frame
+	foundError := false
+
 	for i, line := range lines {
 		line = strings.TrimSpace(line)
 
-		// TypeScript compile errors: "src/file.ts:42:5 - error TS2322: message"
-		if strings.Contains(line, " - error TS") {
-			tsPattern := regexp.MustCompile(`error (TS\d+):\s*(.+)`)
-			matches := tsPattern.FindStringSubmatch(line)
-			if len(matches) >= 3 {
-				result.Type = matches[1]
-				result.Message = strings.TrimSpace(matches[2])
-				foundError = true
-			}
-			continue
-		}
-
 		// Unhandled promise rejections
 		if strings.Contains(line, "UnhandledPromiseRejectionWarning:") {
 			parts := strings.SplitN(line, "UnhandledPromiseRejectionWarning:", 2)
@@ -125,11 +150,7 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 
 		// Stack frames: "    at functionName (file:line:col)"
 		if strings.HasPrefix(line, "at ") {
-			frame := errclean.StripNoise(line)
-			// Skip Node.js internal frames unless they're the only ones
-			if !strings.Contains(frame, "internal/") || len(stackFrames) == 0 {
-				stackFrames = append(stackFrames, frame)
-			}
+			stackFrames = append(stackFrames, parseFrame(maps.resolve(line)))
 		}
 	}
 
@@ -139,10 +160,26 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 	}
 
 	result.Stack = errclean.DeduplicateFrames(stackFrames)
+	if len(result.Stack) > 0 {
+		result.Location = result.Stack[0].Location
+	}
 	result.Message = errclean.StripNoise(result.Message)
 	return result
 }
 
+// parseFrame extracts the function and location from an "at" stack frame
+func parseFrame(line string) errclean.Frame {
+	matches := framePattern.FindStringSubmatch(line)
+	if matches == nil {
+		return errclean.NewFrame(line, errclean.Location{})
+	}
+
+	file := strings.TrimPrefix(matches[2], "file://")
+	frame := errclean.NewFrame(line, errclean.ParseLocation(file+":"+matches[3]+":"+matches[4]))
+	frame.Function = matches[1]
+	return frame
+}
+
 // parseNpmError handles npm-specific error formats
 func parseNpmError(lines []string) *errclean.CleanedError {
 	result := &errclean.CleanedError{
@@ -3,6 +3,8 @@ package javascript
 import (
 	"strings"
 	"testing"
+
+	"github.com/XD637/err/errclean"
 )
 
 func TestJavaScriptParser(t *testing.T) {
@@ -40,7 +42,7 @@ func TestJavaScriptParser(t *testing.T) {
        ~~~~~`,
 			expectedType: "TS2322",
 			expectedMsg:  "Type 'string' is not assignable to type 'number'.",
-			expectStack:  false,
+			expectStack:  true,
 		},
 	}
 
@@ -76,6 +78,11 @@ func TestJavaScriptDetect(t *testing.T) {
 			input:    "src/index.ts:42:5 - error TS2322: Type error",
 			minScore: 100,
 		},
+		{
+			name:     "TypeScript non-pretty error",
+			input:    "src/index.ts(42,5): error TS2322: Type error",
+			minScore: 100,
+		},
 		{
 			name:     "Promise rejection",
 			input:    "UnhandledPromiseRejectionWarning: Error: failed",
@@ -102,3 +109,217 @@ func TestJavaScriptDetect(t *testing.T) {
 		})
 	}
 }
+
+func TestJavaScriptTestRunners(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name            string
+		input           string
+		expectedTest    string
+		expectedType    string
+		expectedMsg     string
+		expectedDetail  string
+		expectedFrame   string
+		expectedResults int
+	}{
+		{
+			name: "Jest assertion",
+			input: ` FAIL  src/sum.test.js
+  ● sum › adds numbers
+
+    expect(received).toBe(expected) // Object.is equality
+
+    Expected: 4
+    Received: 5
+
+      at Object.<anonymous> (src/sum.test.js:4:21)
+
+Test Suites: 1 failed, 1 total`,
+			expectedTest:    "sum › adds numbers",
+			expectedType:    "test failure",
+			expectedMsg:     "expect(received).toBe(expected)",
+			expectedDetail:  "Expected: 4",
+			expectedFrame:   "src/sum.test.js:4:21",
+			expectedResults: 1,
+		},
+		{
+			name: "Jest suite failure ranked above npm",
+			input: `npm ERR! code ELIFECYCLE
+npm ERR! myapp@1.0.0 test: ` + "`jest`" + `
+
+FAIL src/utils/validator.test.js
+  ● Test suite failed to run
+
+    ReferenceError: window is not defined
+
+      at Object.<anonymous> (src/utils/validator.js:3:1)
+      at Runtime._execModule (node_modules/jest-runtime/build/index.js:1299:24)
+
+Test Suites: 1 failed, 5 passed, 6 total`,
+			expectedTest:    "src/utils/validator.test.js › Test suite failed to run",
+			expectedType:    "ReferenceError",
+			expectedMsg:     "window is not defined",
+			expectedFrame:   "src/utils/validator.js:3:1",
+			expectedResults: 2,
+		},
+		{
+			name: "Vitest assertion",
+			input: ` FAIL  src/sum.test.ts > sum > adds numbers
+AssertionError: expected 5 to be 4 // Object.is equality
+
+- Expected
++ Received
+
+- 4
++ 5
+
+ ❯ src/sum.test.ts:4:21`,
+			expectedTest:    "sum › adds numbers",
+			expectedType:    "AssertionError",
+			expectedMsg:     "expected 5 to be 4",
+			expectedDetail:  "+ 5",
+			expectedFrame:   "src/sum.test.ts:4:21",
+			expectedResults: 1,
+		},
+		{
+			name: "Mocha assertion",
+			input: `  0 passing (6ms)
+  1 failing
+
+  1) Array
+       #indexOf()
+         should return -1 when the value is not present:
+
+      AssertionError [ERR_ASSERTION]: 4 == 5
+      + expected - actual
+
+      -4
+      +5
+
+      at Context.<anonymous> (test/test.js:5:14)
+      at processImmediate (node:internal/timers:476:21)`,
+			expectedTest:    "Array › #indexOf() › should return -1 when the value is not present",
+			expectedType:    "AssertionError",
+			expectedMsg:     "4 == 5",
+			expectedDetail:  "-4",
+			expectedFrame:   "test/test.js:5:14",
+			expectedResults: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := parser.ParseAll(tt.input)
+			if len(results) != tt.expectedResults {
+				t.Fatalf("got %d results, want %d", len(results), tt.expectedResults)
+			}
+
+			result := results[0]
+			if result.Test != tt.expectedTest {
+				t.Errorf("Test = %q, want %q", result.Test, tt.expectedTest)
+			}
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if result.Message != tt.expectedMsg {
+				t.Errorf("Message = %q, want %q", result.Message, tt.expectedMsg)
+			}
+
+			if tt.expectedDetail != "" && !strings.Contains(strings.Join(result.Details, "\n"), tt.expectedDetail) {
+				t.Errorf("Details should contain %q, got %v", tt.expectedDetail, result.Details)
+			}
+
+			if len(result.Stack) != 1 || !strings.Contains(result.Stack[0].Text, tt.expectedFrame) {
+				t.Errorf("Stack = %v, want single frame containing %q", result.Stack, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestTestFailureFrameUsesRoot(t *testing.T) {
+	// A project checked out below a vendor directory is only user code
+	// relative to its own root
+	input := ` FAIL  src/sum.test.js
+  ● sum › adds numbers
+
+    expect(received).toBe(expected) // Object.is equality
+
+      at Object.<anonymous> (/home/dev/vendor/app/src/sum.test.js:4:21)`
+
+	if result := (&Parser{}).ParseAllIn(input, ""); len(result[0].Stack) != 0 {
+		t.Errorf("Stack without root = %v, want no user frame", result[0].Stack)
+	}
+
+	result := (&Parser{}).ParseAllIn(input, "/home/dev/vendor/app")[0]
+	if loc := result.Location.String(); loc != "/home/dev/vendor/app/src/sum.test.js:4:21" {
+		t.Errorf("Location = %q, want the test file", loc)
+	}
+}
+
+func TestTypeScriptMultipleErrors(t *testing.T) {
+	parser := &Parser{}
+
+	input := `src/app.ts:3:7 - error TS2741: Property 'x' is missing in type '{}' but required in type 'Foo'.
+
+3 const f: Foo = {};
+        ~
+
+  src/types.ts:3:5
+    3   x: number;
+        ~
+    'x' is declared here.
+
+src/a.ts(1,7): error TS2322: Type '{ a: number; }' is not assignable to type 'Foo'.
+  Object literal may only specify known properties, and 'a' does not exist in type 'Foo'.
+
+Found 3 errors in 2 files.`
+
+	results := parser.ParseAll(input)
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	tests := []struct {
+		expectedType     string
+		expectedLocation string
+		expectedDetail   string
+	}{
+		{"TS2741", "src/app.ts:3:7", "src/types.ts:3:5: 'x' is declared here."},
+		{"TS2322", "src/a.ts:1:7", "Object literal may only specify known properties"},
+		{"tsc", "", "tsc reported 3 errors but 2 were parsed"},
+	}
+
+	for i, tt := range tests {
+		result := results[i]
+		if result.Type != tt.expectedType {
+			t.Errorf("results[%d].Type = %v, want %v", i, result.Type, tt.expectedType)
+		}
+
+		if result.Location.String() != tt.expectedLocation {
+			t.Errorf("results[%d].Location = %v, want %v", i, result.Location, tt.expectedLocation)
+		}
+
+		text := result.Message + "\n" + strings.Join(result.Details, "\n")
+		if !strings.Contains(text, tt.expectedDetail) {
+			t.Errorf("results[%d] should contain %q, got %+v", i, tt.expectedDetail, result)
+		}
+	}
+}
+
+func TestTypeScriptSummaryOnly(t *testing.T) {
+	// Errors in a format we don't recognize, followed by tsc's summary
+	input := `src/app.ts:3:7: something went wrong
+
+Found 1 error in src/app.ts:3`
+
+	results := parseTypeScript(strings.Split(input, "\n"))
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if result := results[0]; result.Type != "tsc" || result.Message != "Found 1 error in src/app.ts:3" || result.Severity != errclean.SeverityError {
+		t.Errorf("result = %s: %q (%s), want the summary as an error", result.Type, result.Message, result.Severity)
+	}
+}
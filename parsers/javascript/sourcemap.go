@@ -0,0 +1,278 @@
+package javascript
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Stack frame with a location: "at fn (dist/main.js:1:48213)" or "at dist/main.js:1:48213"
+var framePattern = regexp.MustCompile(`^at (?:(.+?) \()?(.+?):(\d+):(\d+)\)?$`)
+
+// Source map reference at the end of a generated file
+var sourceMappingURLPattern = regexp.MustCompile(`//[#@] sourceMappingURL=(\S+)`)
+
+// sourceMap is a decoded version 3 source map
+type sourceMap struct {
+	dir      string // directory that relative sources are resolved against
+	root     string
+	sources  []string
+	names    []string
+	segments [][]segment // per generated line, sorted by column
+}
+
+// segment maps a generated column to an original position (all 0-based)
+type segment struct {
+	genCol int
+	source int
+	line   int
+	col    int
+	name   int // -1 if the segment has no name
+}
+
+// sourceMapResolver rewrites frames in bundled or transpiled files to their
+// original source locations. Maps are loaded once per generated file.
+type sourceMapResolver struct {
+	root string // project root; relative bundle paths are read from it
+	maps map[string]*sourceMap
+}
+
+// newSourceMapResolver returns a resolver for the project in root, or in
+// the current directory if root is empty
+func newSourceMapResolver(root string) *sourceMapResolver {
+	return &sourceMapResolver{root: root, maps: make(map[string]*sourceMap)}
+}
+
+// resolve returns the frame rewritten to its original location and symbol
+// name, or the frame unchanged if no source map applies
+func (r *sourceMapResolver) resolve(frame string) string {
+	matches := framePattern.FindStringSubmatch(frame)
+	if matches == nil {
+		return frame
+	}
+
+	file := strings.TrimPrefix(matches[2], "file://")
+	sm := r.load(file)
+	if sm == nil {
+		return frame
+	}
+
+	line, _ := strconv.Atoi(matches[3])
+	col, _ := strconv.Atoi(matches[4])
+	seg, ok := sm.lookup(line-1, col-1)
+	if !ok {
+		return frame
+	}
+
+	name := matches[1]
+	if seg.name >= 0 && seg.name < len(sm.names) {
+		name = sm.names[seg.name]
+	}
+
+	location := fmt.Sprintf("%s:%d:%d", sm.sourcePath(seg.source), seg.line+1, seg.col+1)
+	if name == "" {
+		return "at " + location
+	}
+	return fmt.Sprintf("at %s (%s)", name, location)
+}
+
+// load finds the source map for a generated file, either in an adjacent
+// .map file or through its sourceMappingURL comment
+func (r *sourceMapResolver) load(file string) *sourceMap {
+	if sm, ok := r.maps[file]; ok {
+		return sm
+	}
+
+	sm := r.loadSourceMap(file)
+	r.maps[file] = sm
+	return sm
+}
+
+// loadSourceMap reads the source map of a generated file. Paths stay
+// relative in the result, so frames keep the form the tool printed.
+func (r *sourceMapResolver) loadSourceMap(file string) *sourceMap {
+	if data, err := r.readFile(file + ".map"); err == nil {
+		return parseSourceMap(data, filepath.Dir(file))
+	}
+
+	content, err := r.readFile(file)
+	if err != nil {
+		return nil
+	}
+
+	all := sourceMappingURLPattern.FindAllSubmatch(content, -1)
+	if len(all) == 0 {
+		return nil
+	}
+	url := string(all[len(all)-1][1])
+
+	// Inline map: "data:application/json;charset=utf-8;base64,eyJ2..."
+	if strings.HasPrefix(url, "data:") {
+		comma := strings.Index(url, ",")
+		if comma < 0 || !strings.Contains(url[:comma], ";base64") {
+			return nil
+		}
+		data, err := base64.StdEncoding.DecodeString(url[comma+1:])
+		if err != nil {
+			return nil
+		}
+		return parseSourceMap(data, filepath.Dir(file))
+	}
+
+	mapFile := filepath.Join(filepath.Dir(file), filepath.FromSlash(url))
+	data, err := r.readFile(mapFile)
+	if err != nil {
+		return nil
+	}
+	return parseSourceMap(data, filepath.Dir(mapFile))
+}
+
+// readFile reads a file, resolving relative paths against the project root
+func (r *sourceMapResolver) readFile(name string) ([]byte, error) {
+	if r.root != "" && !filepath.IsAbs(name) {
+		name = filepath.Join(r.root, name)
+	}
+	return os.ReadFile(name)
+}
+
+// parseSourceMap decodes a version 3 source map, or returns nil if it is invalid
+func parseSourceMap(data []byte, dir string) *sourceMap {
+	var raw struct {
+		Version    int      `json:"version"`
+		SourceRoot string   `json:"sourceRoot"`
+		Sources    []string `json:"sources"`
+		Names      []string `json:"names"`
+		Mappings   string   `json:"mappings"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil || raw.Version != 3 {
+		return nil
+	}
+
+	segments, err := decodeMappings(raw.Mappings)
+	if err != nil {
+		return nil
+	}
+
+	return &sourceMap{
+		dir:      dir,
+		root:     raw.SourceRoot,
+		sources:  raw.Sources,
+		names:    raw.Names,
+		segments: segments,
+	}
+}
+
+// lookup returns the segment covering a 0-based generated position
+func (sm *sourceMap) lookup(line, col int) (segment, bool) {
+	if line < 0 || line >= len(sm.segments) {
+		return segment{}, false
+	}
+
+	segs := sm.segments[line]
+	i := sort.Search(len(segs), func(i int) bool { return segs[i].genCol > col }) - 1
+	if i < 0 || segs[i].source < 0 || segs[i].source >= len(sm.sources) {
+		return segment{}, false
+	}
+	return segs[i], true
+}
+
+// sourcePath returns the original file path for a source index
+func (sm *sourceMap) sourcePath(index int) string {
+	source := sm.sources[index]
+
+	// Bundler URLs are relative to the project root:
+	// "webpack://myapp/./src/index.ts" or "webpack:///./src/index.ts"
+	if i := strings.Index(source, "://"); i >= 0 {
+		rest := source[i+3:]
+		if slash := strings.Index(rest, "/"); slash >= 0 {
+			rest = rest[slash+1:]
+		}
+		return filepath.Clean(filepath.FromSlash(rest))
+	}
+
+	if filepath.IsAbs(source) {
+		return source
+	}
+	return filepath.Join(sm.dir, filepath.FromSlash(sm.root), filepath.FromSlash(source))
+}
+
+// decodeMappings decodes the base64 VLQ "mappings" field
+func decodeMappings(mappings string) ([][]segment, error) {
+	var lines [][]segment
+	source, line, col, name := 0, 0, 0, 0
+
+	for _, group := range strings.Split(mappings, ";") {
+		var segs []segment
+		genCol := 0
+
+		for _, field := range strings.Split(group, ",") {
+			if field == "" {
+				continue
+			}
+
+			values, err := decodeVLQ(field)
+			if err != nil {
+				return nil, err
+			}
+
+			genCol += values[0]
+			seg := segment{genCol: genCol, source: -1, name: -1}
+			if len(values) >= 4 {
+				source += values[1]
+				line += values[2]
+				col += values[3]
+				seg.source, seg.line, seg.col = source, line, col
+			}
+			if len(values) >= 5 {
+				name += values[4]
+				seg.name = name
+			}
+			segs = append(segs, seg)
+		}
+
+		sort.SliceStable(segs, func(i, j int) bool { return segs[i].genCol < segs[j].genCol })
+		lines = append(lines, segs)
+	}
+
+	return lines, nil
+}
+
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// decodeVLQ decodes a sequence of base64 VLQ values
+func decodeVLQ(field string) ([]int, error) {
+	var values []int
+	value, shift := 0, 0
+
+	for _, c := range field {
+		digit := strings.IndexRune(base64Chars, c)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid VLQ character %q", c)
+		}
+
+		value += (digit & 31) << shift
+		if digit&32 != 0 {
+			shift += 5
+			continue
+		}
+
+		// The lowest bit is the sign
+		if value&1 != 0 {
+			values = append(values, -(value >> 1))
+		} else {
+			values = append(values, value>>1)
+		}
+		value, shift = 0, 0
+	}
+
+	if shift != 0 || len(values) == 0 {
+		return nil, fmt.Errorf("truncated VLQ field %q", field)
+	}
+	return values, nil
+}
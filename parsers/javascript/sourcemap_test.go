@@ -0,0 +1,107 @@
+package javascript
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Two segments on generated line 1: column 0 maps to src/App.tsx:10:5
+// (handleClick) and column 100 maps to src/App.tsx:20:3 (render)
+const testSourceMap = `{"version":3,"sources":["../src/App.tsx"],"names":["handleClick","render"],"mappings":"AASIA,oGAUFC"}`
+
+func TestSourceMapResolve(t *testing.T) {
+	dir := t.TempDir()
+	dist := filepath.Join(dir, "dist")
+	if err := os.Mkdir(dist, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Adjacent .map file
+	adjacent := filepath.Join(dist, "main.js")
+	writeFile(t, adjacent, "minified();")
+	writeFile(t, adjacent+".map", testSourceMap)
+
+	// Inline sourceMappingURL
+	inline := filepath.Join(dist, "inline.js")
+	encoded := base64.StdEncoding.EncodeToString([]byte(testSourceMap))
+	writeFile(t, inline, "minified();\n//# sourceMappingURL=data:application/json;charset=utf-8;base64,"+encoded+"\n")
+
+	original := filepath.Join(dir, "src", "App.tsx")
+
+	tests := []struct {
+		name     string
+		frame    string
+		expected string
+	}{
+		{
+			name:     "Adjacent map with symbol name",
+			frame:    "at r (" + adjacent + ":1:150)",
+			expected: "at render (" + original + ":20:3)",
+		},
+		{
+			name:     "Inline map without function name",
+			frame:    "at " + inline + ":1:5",
+			expected: "at handleClick (" + original + ":10:5)",
+		},
+		{
+			name:     "No source map",
+			frame:    "at main (" + filepath.Join(dir, "missing.js") + ":1:1)",
+			expected: "at main (" + filepath.Join(dir, "missing.js") + ":1:1)",
+		},
+	}
+
+	resolver := newSourceMapResolver("")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolver.resolve(tt.frame); got != tt.expected {
+				t.Errorf("resolve() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSourceMapRelativeToRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "dist"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(root, "dist", "main.js"), "minified();\n//# sourceMappingURL=main.js.map\n")
+	writeFile(t, filepath.Join(root, "dist", "main.js.map"), testSourceMap)
+
+	// The bundle is only found under the root, not the working directory
+	frame := "at r (dist/main.js:1:150)"
+	if got := newSourceMapResolver("").resolve(frame); got != frame {
+		t.Errorf("resolve() without root = %q, want it unchanged", got)
+	}
+
+	expected := "at render (" + filepath.Join("src", "App.tsx") + ":20:3)"
+	if got := newSourceMapResolver(root).resolve(frame); got != expected {
+		t.Errorf("resolve() = %q, want %q", got, expected)
+	}
+}
+
+func TestDecodeVLQ(t *testing.T) {
+	values, err := decodeVLQ("oGAUFC")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []int{100, 0, 10, -2, 1}
+	if len(values) != len(expected) {
+		t.Fatalf("decodeVLQ() = %v, want %v", values, expected)
+	}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("decodeVLQ() = %v, want %v", values, expected)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
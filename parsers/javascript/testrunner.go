@@ -0,0 +1,215 @@
+package javascript
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Test runner output patterns (Jest, Vitest, Mocha)
+var (
+	// Suite header: "FAIL src/sum.test.js" (Jest) or "FAIL  src/sum.test.ts > sum > adds" (Vitest)
+	failHeaderPattern = regexp.MustCompile(`^FAIL\s+(\S+\.[cm]?[jt]sx?)(?:\s+>\s+(.+?))?(?:\s+\([\d.]+\s*m?s\))?$`)
+
+	// Jest failure heading: "● sum › adds numbers"
+	jestTestPattern = regexp.MustCompile(`^●\s+(.+)$`)
+
+	// Mocha summary line that precedes the failure list: "2 failing"
+	mochaFailingPattern = regexp.MustCompile(`^\d+ failing$`)
+
+	// Mocha failure heading: "1) Array"
+	mochaTestPattern = regexp.MustCompile(`^\d+\) (.+)$`)
+
+	// Error line inside a failure block: "AssertionError [ERR_ASSERTION]: 4 == 5"
+	runnerErrorPattern = regexp.MustCompile(`^([A-Z]\w*(?:Error|Exception))(?:\s*\[\w+\])?:\s*(.*)$`)
+
+	// Jest matcher line: "expect(received).toBe(expected) // Object.is equality"
+	jestMatcherPattern = regexp.MustCompile(`^expect\(.*\)\.\w+`)
+
+	// Vitest source frame: "❯ src/sum.test.ts:4:21"
+	vitestFramePattern = regexp.MustCompile(`^❯\s+(\S+:\d+:\d+)`)
+)
+
+// testBlock holds the raw lines reported for a single failing test
+type testBlock struct {
+	name  string
+	lines []string
+}
+
+// parseTestFailures extracts one diagnostic per failing Jest, Vitest or
+// Mocha test. It returns nil if the output contains no test runner failures.
+func parseTestFailures(lines []string, maps *sourceMapResolver) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	for _, block := range splitTestBlocks(lines) {
+		results = append(results, parseTestBlock(block, maps))
+	}
+	return results
+}
+
+// splitTestBlocks groups the output into per-test failure blocks
+func splitTestBlocks(lines []string) []testBlock {
+	var blocks []testBlock
+	var current *testBlock
+	seen := make(map[string]bool)
+	suite := ""
+	inMocha := false
+
+	// Jest repeats every failure in its summary, so keep the first report only
+	closeBlock := func() {
+		if current != nil && !seen[current.name] {
+			seen[current.name] = true
+			blocks = append(blocks, *current)
+		}
+		current = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		if matches := failHeaderPattern.FindStringSubmatch(trimmed); matches != nil {
+			closeBlock()
+			suite = matches[1]
+			if matches[2] != "" {
+				// Vitest names the test on the FAIL line itself
+				current = &testBlock{name: strings.ReplaceAll(matches[2], " > ", " › ")}
+			}
+			continue
+		}
+
+		if matches := jestTestPattern.FindStringSubmatch(trimmed); matches != nil {
+			closeBlock()
+			name := matches[1]
+			if name == "Console" {
+				// console.log output, not a failure
+				continue
+			}
+			if name == "Test suite failed to run" && suite != "" {
+				name = suite + " › " + name
+			}
+			current = &testBlock{name: name}
+			continue
+		}
+
+		if mochaFailingPattern.MatchString(trimmed) {
+			closeBlock()
+			inMocha = true
+			continue
+		}
+
+		if inMocha {
+			if matches := mochaTestPattern.FindStringSubmatch(trimmed); matches != nil {
+				closeBlock()
+				// Nested describe blocks are printed on the following lines,
+				// and the test title ends with a colon
+				parts := []string{matches[1]}
+				for !strings.HasSuffix(parts[len(parts)-1], ":") && i+1 < len(lines) {
+					next := strings.TrimSpace(lines[i+1])
+					if next == "" {
+						break
+					}
+					parts = append(parts, next)
+					i++
+				}
+				name := strings.Join(parts, " › ")
+				current = &testBlock{name: strings.TrimSuffix(name, ":")}
+				continue
+			}
+		}
+
+		// Summaries and npm output end the current block
+		if strings.HasPrefix(trimmed, "Test Suites:") ||
+			strings.HasPrefix(trimmed, "Test Files") ||
+			strings.HasPrefix(trimmed, "Tests:") ||
+			strings.HasPrefix(trimmed, "⎯") ||
+			strings.HasPrefix(trimmed, "npm ERR!") {
+			closeBlock()
+			continue
+		}
+
+		if current != nil {
+			current.lines = append(current.lines, lines[i])
+		}
+	}
+	closeBlock()
+
+	return blocks
+}
+
+// parseTestBlock extracts the error, expected/received diff and first
+// user-code frame from a failure block
+func parseTestBlock(block testBlock, maps *sourceMapResolver) *errclean.CleanedError {
+	result := &errclean.CleanedError{Test: block.name}
+	classifier := errclean.NewClassifier(maps.root)
+	firstLine := ""
+	inDiff := false
+
+	for _, line := range block.lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		// Stack frames end any diff
+		var frame string
+		if strings.HasPrefix(trimmed, "at ") {
+			frame = trimmed
+		} else if matches := vitestFramePattern.FindStringSubmatch(trimmed); matches != nil {
+			frame = "at " + matches[1]
+		}
+		if frame != "" {
+			inDiff = false
+			parsed := parseFrame(maps.resolve(frame))
+			if len(result.Stack) == 0 && classifier.Kind(parsed) == errclean.FrameUser {
+				result.Stack = []errclean.Frame{parsed}
+				result.Location = parsed.Location
+			}
+			continue
+		}
+
+		if result.Message == "" {
+			if matches := runnerErrorPattern.FindStringSubmatch(trimmed); matches != nil {
+				result.Type = matches[1]
+				result.Message = stripMatcherComment(matches[2])
+				continue
+			}
+			if jestMatcherPattern.MatchString(trimmed) {
+				result.Type = "test failure"
+				result.Message = stripMatcherComment(trimmed)
+				continue
+			}
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "- Expected") ||
+			strings.HasPrefix(trimmed, "+ Received") ||
+			strings.HasPrefix(trimmed, "+ expected - actual"):
+			inDiff = true
+			result.Details = append(result.Details, trimmed)
+		case inDiff && (strings.HasPrefix(trimmed, "-") || strings.HasPrefix(trimmed, "+")):
+			result.Details = append(result.Details, trimmed)
+		case strings.HasPrefix(trimmed, "Expected") || strings.HasPrefix(trimmed, "Received"):
+			result.Details = append(result.Details, trimmed)
+		case firstLine == "" && !strings.HasPrefix(trimmed, ">") && !strings.Contains(trimmed, " | "):
+			firstLine = trimmed
+		}
+	}
+
+	// Some failures (e.g., "Cannot find module") have no error class
+	if result.Message == "" {
+		result.Type = "test failure"
+		result.Message = firstLine
+	}
+
+	for i, detail := range result.Details {
+		result.Details[i] = errclean.StripNoise(detail)
+	}
+	result.Message = errclean.StripNoise(result.Message)
+	return result
+}
+
+// stripMatcherComment removes the equality hint appended by Jest and Vitest,
+// e.g. "// Object.is equality"
+func stripMatcherComment(message string) string {
+	return strings.TrimSpace(strings.SplitN(message, " //", 2)[0])
+}
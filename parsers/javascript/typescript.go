@@ -0,0 +1,169 @@
+package javascript
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// TypeScript compiler output patterns
+var (
+	// --pretty: "src/index.ts:42:5 - error TS2322: message"
+	tsPrettyPattern = regexp.MustCompile(`^(\S.*?):(\d+):(\d+) - (error|warning|message) (TS\d+): (.*)$`)
+
+	// Non-pretty: "src/index.ts(42,5): error TS2322: message"
+	tsPlainPattern = regexp.MustCompile(`^(\S.*?)\((\d+),(\d+)\): (error|warning|message) (TS\d+): (.*)$`)
+
+	// Diagnostics without a file, e.g. "error TS5023: Unknown compiler option 'foo'."
+	tsGlobalPattern = regexp.MustCompile(`^(error|warning|message) (TS\d+): (.*)$`)
+
+	// Summary: "Found 2 errors in 2 files." or "Found 1 error in src/index.ts:42"
+	tsSummaryPattern = regexp.MustCompile(`^Found (\d+) errors?\b`)
+
+	// Related information without --pretty: "  src/types.ts(3,5): 'x' is declared here."
+	tsRelatedPattern = regexp.MustCompile(`^(\S+)\((\d+),(\d+)\): (.+)$`)
+
+	// Related information location with --pretty: "  src/types.ts:3:5"
+	tsRelatedLocationPattern = regexp.MustCompile(`^(\S+\.[cm]?[jt]sx?):(\d+):(\d+)$`)
+
+	// Code frame lines: "42     const count = 1;" and "       ~~~~~"
+	tsCodeFramePattern = regexp.MustCompile(`^(\d+\s|[~\s]+$)`)
+)
+
+// tsSeverities maps tsc categories to diagnostic severities
+var tsSeverities = map[string]errclean.Severity{
+	"error":   errclean.SeverityError,
+	"warning": errclean.SeverityWarning,
+	"message": errclean.SeverityNote,
+}
+
+// parseTypeScript returns one diagnostic per tsc error, with chained
+// messages and related information as details. It returns nil if the
+// output contains no TypeScript diagnostics.
+func parseTypeScript(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	inHeader := false
+	related := ""
+	reported, summary := -1, ""
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if diag := parseTSHeader(trimmed); diag != nil {
+			current = diag
+			results = append(results, current)
+			inHeader = true
+			related = ""
+			continue
+		}
+
+		if matches := tsSummaryPattern.FindStringSubmatch(trimmed); matches != nil {
+			reported, _ = strconv.Atoi(matches[1])
+			summary = trimmed
+			current = nil
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		// A blank line ends the chained message
+		if trimmed == "" {
+			inHeader = false
+			continue
+		}
+
+		if matches := tsRelatedPattern.FindStringSubmatch(trimmed); matches != nil {
+			location := fmt.Sprintf("%s:%s:%s", matches[1], matches[2], matches[3])
+			current.Details = append(current.Details, errclean.StripNoise(location+": "+matches[4]))
+			continue
+		}
+
+		// "  Type 'x' is not assignable to type 'y'."
+		if inHeader {
+			current.Details = append(current.Details, errclean.StripNoise(trimmed))
+			continue
+		}
+
+		// With --pretty, related information is a location, a code frame
+		// and then the message
+		if tsRelatedLocationPattern.MatchString(trimmed) {
+			related = trimmed
+			continue
+		}
+
+		if tsCodeFramePattern.MatchString(trimmed) {
+			continue
+		}
+
+		if related != "" {
+			current.Details = append(current.Details, errclean.StripNoise(related+": "+trimmed))
+			related = ""
+		}
+	}
+
+	// Use tsc's own count to flag diagnostics we failed to parse
+	if reported > 0 {
+		parsed := 0
+		for _, result := range results {
+			if result.Severity == errclean.SeverityError {
+				parsed++
+			}
+		}
+		switch {
+		case parsed == 0:
+			// None of the errors were recognized, so the summary is the failure
+			results = append(results, &errclean.CleanedError{
+				Type:     "tsc",
+				Message:  errclean.StripNoise(summary),
+				Severity: errclean.SeverityError,
+			})
+		case parsed != reported:
+			results = append(results, &errclean.CleanedError{
+				Type:     "tsc",
+				Message:  fmt.Sprintf("tsc reported %d errors but %d were parsed", reported, parsed),
+				Severity: errclean.SeverityWarning,
+			})
+		}
+	}
+
+	errclean.SortBySeverity(results)
+	return results
+}
+
+// parseTSHeader parses the first line of a tsc diagnostic, or returns nil
+func parseTSHeader(line string) *errclean.CleanedError {
+	matches := tsPrettyPattern.FindStringSubmatch(line)
+	if matches == nil {
+		matches = tsPlainPattern.FindStringSubmatch(line)
+	}
+
+	if matches == nil {
+		global := tsGlobalPattern.FindStringSubmatch(line)
+		if global == nil {
+			return nil
+		}
+		return &errclean.CleanedError{
+			Type:     global[2],
+			Message:  errclean.StripNoise(strings.TrimSpace(global[3])),
+			Severity: tsSeverities[global[1]],
+		}
+	}
+
+	lineNum, _ := strconv.Atoi(matches[2])
+	col, _ := strconv.Atoi(matches[3])
+	location := errclean.Location{File: matches[1], Line: lineNum, Column: col}
+
+	return &errclean.CleanedError{
+		Type:     matches[5],
+		Message:  errclean.StripNoise(strings.TrimSpace(matches[6])),
+		Severity: tsSeverities[matches[4]],
+		Location: location,
+		Stack:    []errclean.Frame{errclean.NewFrame(location.String(), location)},
+	}
+}
@@ -0,0 +1,209 @@
+package jvm
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Build tool output patterns
+var (
+	// Maven and sbt line prefix: "[ERROR] ", "[WARNING] ", "[error] ", "[warn] "
+	logPrefixPattern = regexp.MustCompile(`^\[(ERROR|WARNING|INFO|error|warn|info|success)\]\s?`)
+
+	// Kotlin compiler through Gradle: "e: /app/src/Main.kt: (12, 5): Unresolved reference: foo"
+	// or, since Kotlin 1.9, "e: file:///app/src/Main.kt:12:5 Unresolved reference: foo"
+	kotlinPattern = regexp.MustCompile(`^([ew]): (?:file://)?(.+?\.kts?)(?:: \((\d+), (\d+)\):|:(\d+):(\d+)) (.*)$`)
+
+	// javac: "/app/src/main/java/App.java:12: error: cannot find symbol"
+	javacPattern = regexp.MustCompile(`^(.+\.java):(\d+): (error|warning): (.*)$`)
+
+	// Maven compiler plugin: "/app/src/main/java/App.java:[12,5] cannot find symbol"
+	mavenPattern = regexp.MustCompile(`^(.+\.(?:java|kt|scala|groovy)):\[(\d+),(\d+)\] (.*)$`)
+
+	// scalac through sbt: "/app/src/main/scala/Main.scala:12:5: not found: value foo"
+	scalaPattern = regexp.MustCompile(`^(.+\.(?:scala|java)):(\d+):(\d+): (.*)$`)
+
+	// Scala 3 header: "-- [E006] Not Found Error: /app/src/main/scala/Main.scala:12:5 ------"
+	scala3Pattern = regexp.MustCompile(`^-- (?:\[(E\d+)\] )?(.+?): (.+\.scala):(\d+):(\d+)[\s-]*$`)
+
+	// Scala 3 message lines, after the code excerpt: "   |          Not found: foo"
+	scala3MessagePattern = regexp.MustCompile(`^\|\s*(.*)$`)
+
+	// Compiler notes that explain an error: "symbol:   variable foo", "required: String"
+	compilerNotePattern = regexp.MustCompile(`^(symbol|location|found|required|reason)\s*:`)
+
+	// Marker under the offending code: "        ^", "          ^^^"
+	caretPattern = regexp.MustCompile(`^\|?\s*\^+\s*$`)
+)
+
+// stripLogPrefix removes a Maven or sbt log level prefix
+func stripLogPrefix(line string) string {
+	return logPrefixPattern.ReplaceAllString(line, "")
+}
+
+// isCompilerDiagnostic reports whether a line is a Kotlin, javac, Maven or
+// sbt compiler diagnostic header
+func isCompilerDiagnostic(line string) bool {
+	body := stripLogPrefix(line)
+	switch {
+	case kotlinPattern.MatchString(line), javacPattern.MatchString(body), scala3Pattern.MatchString(body):
+		return true
+	case body != line && (mavenPattern.MatchString(body) || scalaPattern.MatchString(body)):
+		// Maven and sbt diagnostics always carry a log prefix
+		return true
+	}
+	return false
+}
+
+// parseDiagnostics returns one diagnostic per compiler error or warning, or
+// nil if the output has none. Maven lists compilation errors twice, so
+// repeats are dropped.
+func parseDiagnostics(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	seen := make(map[string]bool)
+	// Scala 3 prints the message after the code excerpt
+	awaitingMessage := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		level := ""
+		if matches := logPrefixPattern.FindStringSubmatch(trimmed); matches != nil {
+			level = strings.ToLower(matches[1])
+		}
+		body := strings.TrimSpace(stripLogPrefix(trimmed))
+
+		if result := parseDiagnostic(trimmed, body, level); result != nil {
+			current = nil
+			key := result.Type + "\x00" + result.Message + "\x00" + result.Location.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			current = result
+			results = append(results, current)
+			awaitingMessage = scala3Pattern.MatchString(body)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch {
+		case body == "" || level == "info" || level == "success":
+			current = nil
+
+		case compilerNotePattern.MatchString(body):
+			current.Details = append(current.Details, errclean.StripNoise(strings.Join(strings.Fields(body), " ")))
+
+		case scala3MessagePattern.MatchString(body):
+			// The first message line replaces the error category of the
+			// header; the rest are details
+			text := strings.TrimSpace(scala3MessagePattern.FindStringSubmatch(body)[1])
+			if text == "" || caretPattern.MatchString(text) {
+				continue
+			}
+			if awaitingMessage {
+				current.Message = errclean.StripNoise(text)
+				awaitingMessage = false
+			} else {
+				current.Details = append(current.Details, errclean.StripNoise(text))
+			}
+		}
+	}
+
+	errclean.SortBySeverity(results)
+	return results
+}
+
+// parseDiagnostic returns the diagnostic a header line starts, or nil.
+// line is the full line, body the line without its log prefix.
+func parseDiagnostic(line, body, level string) *errclean.CleanedError {
+	var file, lineNum, column, message, severity string
+
+	if matches := kotlinPattern.FindStringSubmatch(line); matches != nil {
+		file, message = matches[2], matches[7]
+		lineNum, column = matches[3]+matches[5], matches[4]+matches[6]
+		severity = map[string]string{"e": "error", "w": "warning"}[matches[1]]
+	} else if matches := javacPattern.FindStringSubmatch(body); matches != nil {
+		file, lineNum, severity, message = matches[1], matches[2], matches[3], matches[4]
+	} else if matches := scala3Pattern.FindStringSubmatch(body); matches != nil {
+		// The message follows the code excerpt; keep the category until then
+		result := newDiagnostic(matches[3], matches[4], matches[5], matches[2], levelSeverity(level))
+		if matches[1] != "" {
+			result.Type = matches[1]
+		}
+		return result
+	} else if level == "" {
+		return nil
+	} else if matches := mavenPattern.FindStringSubmatch(body); matches != nil {
+		file, lineNum, column, message = matches[1], matches[2], matches[3], matches[4]
+		severity = levelSeverity(level)
+	} else if matches := scalaPattern.FindStringSubmatch(body); matches != nil {
+		file, lineNum, column, message = matches[1], matches[2], matches[3], matches[4]
+		severity = levelSeverity(level)
+	} else {
+		return nil
+	}
+
+	return newDiagnostic(file, lineNum, column, message, severity)
+}
+
+func newDiagnostic(file, line, column, message, severity string) *errclean.CleanedError {
+	lineNum, _ := strconv.Atoi(line)
+	columnNum, _ := strconv.Atoi(column)
+	location := errclean.Location{File: file, Line: lineNum, Column: columnNum}
+
+	result := &errclean.CleanedError{
+		Type:     severity,
+		Message:  errclean.StripNoise(strings.TrimSpace(message)),
+		Location: location,
+		Stack:    []errclean.Frame{errclean.NewFrame(location.String(), location)},
+	}
+	if severity == "warning" {
+		result.Severity = errclean.SeverityWarning
+	}
+	return result
+}
+
+// levelSeverity maps a Maven or sbt log level to a diagnostic type
+func levelSeverity(level string) string {
+	if level == "warning" || level == "warn" {
+		return "warning"
+	}
+	return "error"
+}
+
+// parseBuildFailure returns Gradle's "What went wrong" block or Maven's
+// failed goal, or nil if the output has neither
+func parseBuildFailure(lines []string) *errclean.CleanedError {
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "* What went wrong:" {
+			result := &errclean.CleanedError{Type: "build failed"}
+			for _, next := range lines[i+1:] {
+				next = strings.TrimSpace(next)
+				if next == "" || strings.HasPrefix(next, "* ") {
+					break
+				}
+				if result.Message == "" {
+					result.Message = errclean.StripNoise(next)
+				} else {
+					// Nested causes: "> Compilation error. See log for more details"
+					result.Details = append(result.Details, errclean.StripNoise(strings.TrimSpace(strings.TrimLeft(next, "> "))))
+				}
+			}
+			return result
+		}
+
+		if body := stripLogPrefix(trimmed); body != trimmed && strings.HasPrefix(body, "Failed to execute goal ") {
+			return &errclean.CleanedError{Type: "build failed", Message: errclean.StripNoise(body)}
+		}
+	}
+	return nil
+}
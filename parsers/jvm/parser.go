@@ -0,0 +1,164 @@
+package jvm
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles JVM stack traces (Java, Kotlin, Scala) and Gradle, Maven
+// and sbt build output
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+// JVM class names: lowercase packages and a capitalized class, "java.lang.IllegalStateException"
+const className = `(?:[a-z_$][\w$]*\.)+[A-Z][\w$]*`
+
+var (
+	// Exception line: `Exception in thread "main" java.lang.IllegalStateException: not started`
+	exceptionPattern = regexp.MustCompile(`^(?:Exception in thread "[^"]*" )?(` + className + `)(?:: (.*))?$`)
+
+	// Cause of the exception above: "Caused by: java.io.FileNotFoundException: config.yml"
+	causedByPattern = regexp.MustCompile(`^Caused by: (` + className + `)(?:: (.*))?$`)
+
+	// Stack frame: "at com.example.App.run(App.java:12)", "at java.base/java.lang.Thread.run(Thread.java:833)"
+	framePattern = regexp.MustCompile(`^at (\S+?)\(([^)]*)\)$`)
+
+	// Location in a frame: "App.java:12"
+	frameLocationPattern = regexp.MustCompile(`^(.+\.\w+):(\d+)$`)
+)
+
+func (p *Parser) Name() string {
+	return "jvm"
+}
+
+func (p *Parser) Detect(text string) int {
+	best := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, `Exception in thread "`):
+			return 100
+		case trimmed == "* What went wrong:" || strings.HasPrefix(trimmed, "FAILURE: Build failed"):
+			return 100
+		case trimmed == "[INFO] BUILD FAILURE" || trimmed == "[ERROR] COMPILATION ERROR :":
+			return 100
+		case isCompilerDiagnostic(trimmed):
+			return 100
+		case causedByPattern.MatchString(trimmed):
+			best = max(best, 90)
+		case framePattern.MatchString(trimmed):
+			if matches := framePattern.FindStringSubmatch(trimmed); frameLocationPattern.MatchString(matches[2]) && strings.Contains(matches[1], ".") {
+				best = max(best, 90)
+			}
+		}
+	}
+	return best
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per compiler error or warning, or per
+// exception, falling back to the build tool's summary of the failure
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	lines := strings.Split(text, "\n")
+
+	if results := parseDiagnostics(lines); len(results) > 0 {
+		return results
+	}
+	if results := parseExceptions(lines); len(results) > 0 {
+		return results
+	}
+	if result := parseBuildFailure(lines); result != nil {
+		return []*errclean.CleanedError{result}
+	}
+	return []*errclean.CleanedError{{Type: "error", Message: errclean.StripNoise(strings.TrimSpace(text))}}
+}
+
+// parseExceptions returns one diagnostic per stack trace. Causes become
+// details, and their frames follow the frames of the exception they caused.
+func parseExceptions(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+
+	for i, line := range lines {
+		trimmed := stripLogPrefix(strings.TrimSpace(line))
+
+		if current != nil {
+			if matches := causedByPattern.FindStringSubmatch(trimmed); matches != nil {
+				cause := matches[1]
+				if matches[2] != "" {
+					cause += ": " + errclean.StripNoise(matches[2])
+				}
+				current.Details = append(current.Details, "Caused by: "+cause)
+				continue
+			}
+
+			if matches := framePattern.FindStringSubmatch(trimmed); matches != nil {
+				current.Stack = append(current.Stack, parseFrame(matches))
+				continue
+			}
+
+			// "... 12 more" frames shared with the enclosing trace
+			if strings.HasPrefix(trimmed, "... ") && strings.HasSuffix(trimmed, " more") {
+				continue
+			}
+		}
+
+		// A class name alone is only an exception if a stack trace follows,
+		// which keeps e.g. "[INFO] com.example.App" log lines out
+		matches := exceptionPattern.FindStringSubmatch(trimmed)
+		if matches == nil {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "Exception in thread ") && !followedByFrame(lines, i) {
+			continue
+		}
+
+		current = &errclean.CleanedError{
+			Type:    matches[1],
+			Message: errclean.StripNoise(matches[2]),
+		}
+		results = append(results, current)
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// followedByFrame reports whether the line after lines[i] is a stack frame
+func followedByFrame(lines []string, i int) bool {
+	return i+1 < len(lines) && framePattern.MatchString(stripLogPrefix(strings.TrimSpace(lines[i+1])))
+}
+
+// parseFrame builds a frame from the matches of framePattern. Frames of
+// native or generated code have no location.
+func parseFrame(matches []string) errclean.Frame {
+	// Drop the class loader and module: "app//com.example.App.run", "java.base/java.lang.Thread.run"
+	function := matches[1]
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		function = function[i+1:]
+	}
+
+	var location errclean.Location
+	if loc := frameLocationPattern.FindStringSubmatch(matches[2]); loc != nil {
+		lineNum, _ := strconv.Atoi(loc[2])
+		location = errclean.Location{File: loc[1], Line: lineNum}
+	}
+
+	frame := errclean.NewFrame(function+"("+matches[2]+")", location)
+	frame.Function = function
+	return frame
+}
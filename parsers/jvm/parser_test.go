@@ -0,0 +1,231 @@
+package jvm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestJVMParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Java exception",
+			input: `Exception in thread "main" java.lang.IllegalStateException: Connection not started
+	at com.example.db.Pool.acquire(Pool.java:42)
+	at com.example.App.main(App.java:12)`,
+			expectedType:  "java.lang.IllegalStateException",
+			expectedMsg:   "Connection not started",
+			expectedFrame: "Pool.java:42",
+		},
+		{
+			name: "Kotlin exception with module frames",
+			input: `Exception in thread "main" kotlin.KotlinNullPointerException
+	at java.base/java.util.Objects.requireNonNull(Objects.java:209)
+	at app//com.example.MainKt.main(Main.kt:7)`,
+			expectedType:  "kotlin.KotlinNullPointerException",
+			expectedMsg:   "",
+			expectedFrame: "Main.kt:7",
+		},
+		{
+			name: "Gradle Kotlin compiler error",
+			input: `> Task :app:compileKotlin FAILED
+e: file:///home/dev/app/src/main/kotlin/Main.kt:12:5 Unresolved reference: foo
+
+FAILURE: Build failed with an exception.
+
+* What went wrong:
+Execution failed for task ':app:compileKotlin'.
+> Compilation error. See log for more details`,
+			expectedType:  "error",
+			expectedMsg:   "Unresolved reference: foo",
+			expectedFrame: "/home/dev/app/src/main/kotlin/Main.kt:12:5",
+		},
+		{
+			name:          "Old Kotlin compiler format",
+			input:         "e: /home/dev/app/src/main/kotlin/Main.kt: (3, 9): Type mismatch: inferred type is String but Int was expected",
+			expectedType:  "error",
+			expectedMsg:   "Type mismatch",
+			expectedFrame: "/home/dev/app/src/main/kotlin/Main.kt:3:9",
+		},
+		{
+			name: "javac error",
+			input: `/home/dev/app/src/main/java/App.java:12: error: cannot find symbol
+        foo();
+        ^
+  symbol:   method foo()
+  location: class App
+1 error`,
+			expectedType:  "error",
+			expectedMsg:   "cannot find symbol",
+			expectedFrame: "/home/dev/app/src/main/java/App.java:12",
+		},
+		{
+			name: "sbt Scala 2 error",
+			input: `[info] compiling 1 Scala source to /app/target/scala-2.13/classes ...
+[error] /app/src/main/scala/Main.scala:5:13: not found: value foo
+[error]     println(foo)
+[error]             ^
+[error] one error found
+[error] (Compile / compileIncremental) Compilation failed`,
+			expectedType:  "error",
+			expectedMsg:   "not found: value foo",
+			expectedFrame: "/app/src/main/scala/Main.scala:5:13",
+		},
+		{
+			name: "sbt Scala 3 error",
+			input: `[error] -- [E006] Not Found Error: /app/src/main/scala/Main.scala:5:10 ---------------
+[error] 5 |  println(foo)
+[error]   |          ^^^
+[error]   |          Not found: foo
+[error] one error found`,
+			expectedType:  "E006",
+			expectedMsg:   "Not found: foo",
+			expectedFrame: "/app/src/main/scala/Main.scala:5:10",
+		},
+		{
+			name: "Gradle failure without diagnostics",
+			input: `FAILURE: Build failed with an exception.
+
+* What went wrong:
+Could not resolve all files for configuration ':app:compileClasspath'.
+> Could not find com.example:missing:1.0.
+
+* Try:
+> Run with --stacktrace option to get the stack trace.`,
+			expectedType:  "build failed",
+			expectedMsg:   "Could not resolve all files for configuration ':app:compileClasspath'.",
+			expectedFrame: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+			if loc := result.PrimaryLocation(); loc.String() != tt.expectedFrame {
+				t.Errorf("PrimaryLocation() = %v, want %v", loc, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestCausedBy(t *testing.T) {
+	input := `Exception in thread "main" java.lang.RuntimeException: Failed to load config
+	at com.example.Config.load(Config.java:30)
+	at com.example.App.main(App.java:8)
+Caused by: java.io.FileNotFoundException: config.yml (No such file or directory)
+	at java.base/java.io.FileInputStream.open0(Native Method)
+	at java.base/java.io.FileInputStream.open(FileInputStream.java:216)
+	at com.example.Config.load(Config.java:27)
+	... 1 more`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 exception, got %d", len(results))
+	}
+
+	result := results[0]
+	if len(result.Details) != 1 || result.Details[0] != "Caused by: java.io.FileNotFoundException: config.yml (No such file or directory)" {
+		t.Errorf("Details = %q", result.Details)
+	}
+
+	errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+	expected := []errclean.FrameKind{errclean.FrameUser, errclean.FrameUser, errclean.FrameRuntime, errclean.FrameRuntime, errclean.FrameUser}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+	if result.Stack[2].Location.File != "" || result.Stack[2].Function != "java.io.FileInputStream.open0" {
+		t.Errorf("native frame = %+v", result.Stack[2])
+	}
+}
+
+func TestMavenCompilationFailure(t *testing.T) {
+	input := `[INFO] --- maven-compiler-plugin:3.11.0:compile (default-compile) @ app ---
+[WARNING] /app/src/main/java/com/example/Legacy.java:[7,20] [deprecation] Date(String) in Date has been deprecated
+[INFO] -------------------------------------------------------------
+[ERROR] COMPILATION ERROR :
+[INFO] -------------------------------------------------------------
+[ERROR] /app/src/main/java/com/example/App.java:[12,9] cannot find symbol
+  symbol:   variable foo
+  location: class com.example.App
+[INFO] 1 error
+[INFO] -------------------------------------------------------------
+[INFO] BUILD FAILURE
+[INFO] -------------------------------------------------------------
+[ERROR] Failed to execute goal org.apache.maven.plugins:maven-compiler-plugin:3.11.0:compile (default-compile) on project app: Compilation failure
+[ERROR] /app/src/main/java/com/example/App.java:[12,9] cannot find symbol
+[ERROR]   symbol:   variable foo
+[ERROR]   location: class com.example.App
+[ERROR] -> [Help 1]`
+
+	parser := &Parser{}
+	if confidence := parser.Detect(input); confidence != 100 {
+		t.Errorf("Detect() = %d, want 100", confidence)
+	}
+
+	results := parser.ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(results))
+	}
+
+	err := results[0]
+	if err.Type != "error" || err.Message != "cannot find symbol" || err.Location.String() != "/app/src/main/java/com/example/App.java:12:9" {
+		t.Errorf("error = %s: %s at %s", err.Type, err.Message, err.Location)
+	}
+	if len(err.Details) != 2 || err.Details[0] != "symbol: variable foo" {
+		t.Errorf("Details = %q", err.Details)
+	}
+	if results[1].Severity != errclean.SeverityWarning || !strings.Contains(results[1].Message, "deprecated") {
+		t.Errorf("warning = %s: %s", results[1].Severity, results[1].Message)
+	}
+}
+
+func TestFrameworkFramesAreDependencies(t *testing.T) {
+	input := `java.lang.AssertionError: expected:<3> but was:<2>
+	at org.junit.Assert.fail(Assert.java:89)
+	at com.example.CalculatorTest.testAdd(CalculatorTest.java:14)
+	at org.junit.runners.ParentRunner.run(ParentRunner.java:413)`
+
+	result := (&Parser{}).Parse(input)
+	errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+
+	expected := []errclean.FrameKind{errclean.FrameDependency, errclean.FrameUser, errclean.FrameDependency}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+	if loc := result.PrimaryLocation(); loc.String() != "CalculatorTest.java:14" {
+		t.Errorf("PrimaryLocation() = %v", loc)
+	}
+}
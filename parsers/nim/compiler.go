@@ -0,0 +1,109 @@
+package nim
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Compiler output patterns
+var (
+	// Compiler diagnostic: "/app/src/app.nim(12, 7) Error: type mismatch: got <string>",
+	// "app.nim(3, 8) Warning: imported and not used: 'os' [UnusedImport]"
+	diagnosticPattern = regexp.MustCompile(`^(\S+\.nim[s]?)\((\d+), (\d+)\) (Error|Warning): (.*?)(?: \[(\w+)\])?$`)
+
+	// Generic or template instantiation leading to the next diagnostic:
+	// "app.nim(20, 3) template/generic instantiation of `foo` from here"
+	instantiationPattern = regexp.MustCompile(`^(\S+\.nim[s]?)\((\d+), (\d+)\) (template/generic instantiation.*)$`)
+
+	// Overload candidate of a type mismatch: "proc foo(x: int)", or since Nim 2.0 "[1] proc foo(x: int)"
+	candidatePattern = regexp.MustCompile(`^(?:\[\d+\] )?((?:proc|func|method|iterator|converter|template|macro) .*)$`)
+
+	// Argument of a type mismatch since Nim 2.0, below "Expression:": "[1] y: string"
+	argumentPattern = regexp.MustCompile(`^\[(\d+)\] (\w+): (.+)$`)
+)
+
+// severities maps compiler levels to diagnostic severities
+var severities = map[string]errclean.Severity{
+	"Error":   errclean.SeverityError,
+	"Warning": errclean.SeverityWarning,
+}
+
+// parseDiagnostics returns one diagnostic per compiler error or warning,
+// or nil if the output has none. A type mismatch lists every overload
+// the compiler tried; it is summarized to the expression, its arguments
+// and the first candidate with what did not match.
+func parseDiagnostics(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	var instantiations []errclean.Frame
+	inCandidates, candidates := false, 0
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := instantiationPattern.FindStringSubmatch(trimmed); matches != nil {
+			location := parseLocation(matches[1], matches[2], matches[3])
+			instantiations = append(instantiations, newFrame(matches[4]+" at "+location.String(), location))
+			current = nil
+			continue
+		}
+
+		if matches := diagnosticPattern.FindStringSubmatch(trimmed); matches != nil {
+			location := parseLocation(matches[1], matches[2], matches[3])
+			current = &errclean.CleanedError{
+				Type:     strings.ToLower(matches[4]),
+				Message:  errclean.StripNoise(matches[5]),
+				Severity: severities[matches[4]],
+				Location: location,
+				Stack:    append([]errclean.Frame{newFrame(location.String(), location)}, instantiations...),
+			}
+			// Warnings are named by their tag: [UnusedImport]
+			if matches[6] != "" && matches[4] == "Warning" {
+				current.Type = matches[6]
+			}
+			results = append(results, current)
+			instantiations = nil
+			inCandidates, candidates = false, 0
+			continue
+		}
+
+		if current == nil || trimmed == "" {
+			continue
+		}
+
+		switch {
+		case trimmed == "but expected one of:" || strings.HasPrefix(trimmed, "Expected one of"):
+			inCandidates = true
+		case inCandidates && candidatePattern.MatchString(trimmed):
+			candidates++
+			if candidates == 1 {
+				current.Details = append(current.Details, "expected "+candidatePattern.FindStringSubmatch(trimmed)[1])
+			}
+		case strings.HasPrefix(trimmed, "expression: "), strings.HasPrefix(trimmed, "Expression: "):
+			current.Details = append(current.Details, "expression: "+trimmed[len("expression: "):])
+		case argumentPattern.MatchString(trimmed):
+			matches := argumentPattern.FindStringSubmatch(trimmed)
+			current.Details = append(current.Details, "argument "+matches[1]+": "+matches[2]+" is "+matches[3])
+		case candidates == 1 && (strings.HasPrefix(trimmed, "required type for ") || strings.HasPrefix(trimmed, "but expression '")):
+			// Nim 1.x explains the first mismatch below each candidate
+			current.Details = append(current.Details, trimmed)
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	errclean.SortBySeverity(results)
+	return results
+}
+
+// parseLocation builds a location from a file and the line and column
+// numbers the compiler prints after it
+func parseLocation(file, line, column string) errclean.Location {
+	lineNum, _ := strconv.Atoi(line)
+	columnNum, _ := strconv.Atoi(column)
+	return errclean.Location{File: file, Line: lineNum, Column: columnNum}
+}
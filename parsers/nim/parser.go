@@ -0,0 +1,89 @@
+package nim
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles Nim compiler diagnostics and unhandled exception tracebacks
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+func (p *Parser) Name() string {
+	return "nim"
+}
+
+func (p *Parser) Detect(text string) int {
+	best := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case diagnosticPattern.MatchString(trimmed), exceptionPattern.MatchString(trimmed):
+			return 100
+		case framePattern.MatchString(trimmed):
+			best = max(best, 90)
+		case strings.HasPrefix(trimmed, "SIGSEGV: Illegal storage access."):
+			best = max(best, 80)
+		}
+	}
+	return best
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per compiler error or warning or, when
+// the code built, the unhandled exception that ended the program
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	lines := strings.Split(text, "\n")
+
+	if results := parseDiagnostics(lines); len(results) > 0 {
+		return results
+	}
+	if results := parseTracebacks(lines); len(results) > 0 {
+		return results
+	}
+	return []*errclean.CleanedError{{Type: "error", Message: errclean.StripNoise(strings.TrimSpace(text))}}
+}
+
+// Path fragments of the standard library, e.g.
+// ~/.choosenim/toolchains/nim-2.0.0/lib/system/fatal.nim, and of Nimble
+// packages, e.g. ~/.nimble/pkgs2/jester-0.6.0-<hash>/jester.nim. The
+// parser decides, not the classifier, because the same directory names
+// are user code in other languages.
+var (
+	stdlibPaths  = []string{"/lib/system/", "/lib/pure/", "/lib/std/"}
+	packagePaths = []string{".nimble/"}
+)
+
+// newFrame creates a frame classified by the file it is in
+func newFrame(text string, location errclean.Location) errclean.Frame {
+	frame := errclean.NewFrame(text, location)
+	frame.Kind = frameKind(location.File)
+	return frame
+}
+
+// frameKind returns where the code of a file comes from
+func frameKind(file string) errclean.FrameKind {
+	file = filepath.ToSlash(file)
+	for _, fragment := range stdlibPaths {
+		if strings.Contains(file, fragment) {
+			return errclean.FrameRuntime
+		}
+	}
+	for _, fragment := range packagePaths {
+		if strings.Contains(file, fragment) {
+			return errclean.FrameDependency
+		}
+	}
+	return errclean.FrameUser
+}
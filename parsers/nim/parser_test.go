@@ -0,0 +1,183 @@
+package nim
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestNimParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Undeclared identifier",
+			input: `Hint: used config file '/etc/nim/nim.cfg' [Conf]
+/app/src/app.nim(8, 3) Error: undeclared identifier: 'lenght'`,
+			expectedType:  "error",
+			expectedMsg:   "undeclared identifier: 'lenght'",
+			expectedFrame: "/app/src/app.nim:8:3",
+		},
+		{
+			name: "Type mismatch",
+			input: `/app/src/app.nim(12, 7) Error: type mismatch: got <string>
+but expected one of:
+proc foo(x: int)
+  first type mismatch at position: 1
+  required type for x: int
+  but expression 'y' is of type: string
+
+expression: foo(y)`,
+			expectedType:  "error",
+			expectedMsg:   "type mismatch: got <string>",
+			expectedFrame: "/app/src/app.nim:12:7",
+		},
+		{
+			name: "Unhandled exception",
+			input: `Traceback (most recent call last)
+/app/src/app.nim(20) app
+/app/src/app.nim(12) process
+/home/dev/.choosenim/toolchains/nim-2.0.0/lib/system/fatal.nim(53) sysFatal
+Error: unhandled exception: index 5 not in 0 .. 2 [IndexDefect]`,
+			expectedType:  "IndexDefect",
+			expectedMsg:   "index 5 not in 0 .. 2",
+			expectedFrame: "/app/src/app.nim:12",
+		},
+		{
+			name: "Segfault",
+			input: `Traceback (most recent call last)
+/app/src/app.nim(30) app
+SIGSEGV: Illegal storage access. (Attempt to read from nil?)`,
+			expectedType:  "SIGSEGV",
+			expectedMsg:   "Illegal storage access.",
+			expectedFrame: "/app/src/app.nim:30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+			if loc := result.PrimaryLocation(); loc.String() != tt.expectedFrame {
+				t.Errorf("PrimaryLocation() = %v, want %v", loc, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestTypeMismatchDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "Nim 1.x",
+			input: `app.nim(12, 7) Error: type mismatch: got <string>
+but expected one of:
+proc foo(x: int)
+  first type mismatch at position: 1
+  required type for x: int
+  but expression 'y' is of type: string
+proc foo(x: float)
+  first type mismatch at position: 1
+  required type for x: float
+  but expression 'y' is of type: string
+
+expression: foo(y)`,
+			expected: []string{
+				"expected proc foo(x: int)",
+				"required type for x: int",
+				"but expression 'y' is of type: string",
+				"expression: foo(y)",
+			},
+		},
+		{
+			name: "Nim 2.0",
+			input: `app.nim(12, 7) Error: type mismatch
+Expression: foo(y)
+  [1] y: string
+
+Expected one of (first mismatch at [position]):
+[1] proc foo(x: int)
+[1] proc foo(x: float)`,
+			expected: []string{
+				"expression: foo(y)",
+				"argument 1: y is string",
+				"expected proc foo(x: int)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := (&Parser{}).Parse(tt.input)
+			if strings.Join(result.Details, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("Details = %q, want %q", result.Details, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInstantiationsAndWarnings(t *testing.T) {
+	input := `app.nim(3, 8) Warning: imported and not used: 'os' [UnusedImport]
+app.nim(20, 3) template/generic instantiation of ` + "`sum`" + ` from here
+mathx.nim(5, 10) Error: type mismatch: got <T, int>`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(results))
+	}
+
+	err := results[0]
+	if err.Severity != errclean.SeverityError || len(err.Stack) != 2 || err.Stack[1].Location.String() != "app.nim:20:3" {
+		t.Errorf("error = %s, stack %v", err.Type, err.Stack)
+	}
+
+	warning := results[1]
+	if warning.Type != "UnusedImport" || warning.Message != "imported and not used: 'os'" || warning.Severity != errclean.SeverityWarning {
+		t.Errorf("warning = %s: %q (%s)", warning.Type, warning.Message, warning.Severity)
+	}
+}
+
+func TestTracebackFrameKinds(t *testing.T) {
+	input := `Traceback (most recent call last)
+/app/src/app.nim(20) app
+/home/dev/.nimble/pkgs2/jester-0.6.0-abc123/jester.nim(495) handleRequest
+/home/dev/.choosenim/toolchains/nim-2.0.0/lib/pure/strutils.nim(1120) parseInt
+Error: unhandled exception: invalid integer: abc [ValueError]`
+
+	result := (&Parser{}).Parse(input)
+	errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+
+	// Innermost first
+	expected := []errclean.FrameKind{errclean.FrameRuntime, errclean.FrameDependency, errclean.FrameUser}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package nim
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Runtime patterns
+var (
+	// Traceback frame: "/app/src/app.nim(20) app"
+	framePattern = regexp.MustCompile(`^(\S+\.nim)\((\d+)\) (\S+)$`)
+
+	// Exception ending the traceback: "Error: unhandled exception: index 5 not in 0 .. 2 [IndexDefect]"
+	exceptionPattern = regexp.MustCompile(`^Error: unhandled exception: (.*?)(?: \[(\w+)\])?$`)
+
+	// Crash ending the traceback: "SIGSEGV: Illegal storage access. (Attempt to read from nil?)"
+	signalPattern = regexp.MustCompile(`^(SIG[A-Z]+): (.*)$`)
+)
+
+// parseTracebacks returns one diagnostic per unhandled exception or
+// crash, with the traceback printed before it, innermost frame first
+func parseTracebacks(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var frames []errclean.Frame
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "Traceback (most recent call last)") {
+			frames = nil
+			continue
+		}
+
+		if matches := framePattern.FindStringSubmatch(trimmed); matches != nil {
+			lineNum, _ := strconv.Atoi(matches[2])
+			location := errclean.Location{File: matches[1], Line: lineNum}
+
+			frame := newFrame(trimmed, location)
+			frame.Function = matches[3]
+			frames = append(frames, frame)
+			continue
+		}
+
+		var result *errclean.CleanedError
+		if matches := exceptionPattern.FindStringSubmatch(trimmed); matches != nil {
+			result = &errclean.CleanedError{Type: matches[2], Message: errclean.StripNoise(matches[1])}
+			if result.Type == "" {
+				result.Type = "Exception"
+			}
+		} else if matches := signalPattern.FindStringSubmatch(trimmed); matches != nil {
+			result = &errclean.CleanedError{Type: matches[1], Message: errclean.StripNoise(matches[2])}
+		}
+		if result == nil {
+			continue
+		}
+
+		// Nim prints the innermost frame last
+		for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+			frames[i], frames[j] = frames[j], frames[i]
+		}
+		result.Stack = errclean.DeduplicateFrames(frames)
+		results = append(results, result)
+		frames = nil
+	}
+	return results
+}
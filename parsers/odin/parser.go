@@ -0,0 +1,101 @@
+package odin
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles Odin compiler diagnostics and runtime panics
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+// Compiler diagnostic or runtime error: "/app/main.odin(12:5) Error: Undeclared name: foo",
+// "/app/main.odin(12:14) Index 5 is out of range 0..<3"
+var diagnosticPattern = regexp.MustCompile(`^(\S+\.odin)\((\d+):(\d+)\) (?:(Error|Syntax Error|Warning|Syntax Warning): )?(.*)$`)
+
+// severities maps compiler levels to diagnostic severities
+var severities = map[string]errclean.Severity{
+	"Error":          errclean.SeverityError,
+	"Syntax Error":   errclean.SeverityError,
+	"Warning":        errclean.SeverityWarning,
+	"Syntax Warning": errclean.SeverityWarning,
+}
+
+func (p *Parser) Name() string {
+	return "odin"
+}
+
+func (p *Parser) Detect(text string) int {
+	for _, line := range strings.Split(text, "\n") {
+		if diagnosticPattern.MatchString(strings.TrimSpace(line)) {
+			return 100
+		}
+	}
+	return 0
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per compiler error or warning, or per
+// runtime panic. Odin prints both with a location and no stack trace; the
+// source excerpt after a diagnostic is skipped, its suggestions kept.
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	seen := make(map[string]bool)
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := diagnosticPattern.FindStringSubmatch(trimmed); matches != nil {
+			lineNum, _ := strconv.Atoi(matches[2])
+			column, _ := strconv.Atoi(matches[3])
+			location := errclean.Location{File: matches[1], Line: lineNum, Column: column}
+
+			current = &errclean.CleanedError{
+				Type:     strings.ToLower(matches[4]),
+				Message:  errclean.StripNoise(matches[5]),
+				Severity: severities[matches[4]],
+				Location: location,
+				Stack:    []errclean.Frame{errclean.NewFrame(location.String(), location)},
+			}
+			if matches[4] == "" {
+				// Runtime errors have no level: "Panic: boom", "runtime assertion", "Index 5 is out of range"
+				current.Type = "panic"
+				for _, prefix := range []string{"Panic: ", "panic: "} {
+					current.Message = strings.TrimPrefix(current.Message, prefix)
+				}
+			}
+
+			key := current.Type + "\x00" + current.Message + "\x00" + location.String()
+			if seen[key] {
+				current = nil
+				continue
+			}
+			seen[key] = true
+			results = append(results, current)
+			continue
+		}
+
+		// "Suggestion: Did you mean 'fooo'?"
+		if current != nil && strings.HasPrefix(trimmed, "Suggestion: ") {
+			current.Details = append(current.Details, errclean.StripNoise(trimmed))
+		}
+	}
+
+	if len(results) == 0 {
+		return []*errclean.CleanedError{{Type: "error", Message: errclean.StripNoise(strings.TrimSpace(text))}}
+	}
+	errclean.SortBySeverity(results)
+	return results
+}
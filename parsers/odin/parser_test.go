@@ -0,0 +1,94 @@
+package odin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestOdinParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Undeclared name",
+			input: `/app/main.odin(12:5) Error: Undeclared name: fooo
+	fooo()
+	^~~^
+	Suggestion: Did you mean 'foo'?`,
+			expectedType:  "error",
+			expectedMsg:   "Undeclared name: fooo",
+			expectedFrame: "/app/main.odin:12:5",
+		},
+		{
+			name:          "Syntax error",
+			input:         `/app/main.odin(3:14) Syntax Error: Expected ';', got identifier`,
+			expectedType:  "syntax error",
+			expectedMsg:   "Expected ';', got identifier",
+			expectedFrame: "/app/main.odin:3:14",
+		},
+		{
+			name:          "Bounds check",
+			input:         `/app/main.odin(20:9) Index 5 is out of range 0..<3`,
+			expectedType:  "panic",
+			expectedMsg:   "Index 5 is out of range 0..<3",
+			expectedFrame: "/app/main.odin:20:9",
+		},
+		{
+			name:          "Panic",
+			input:         `/app/server.odin(41:3) Panic: connection closed`,
+			expectedType:  "panic",
+			expectedMsg:   "connection closed",
+			expectedFrame: "/app/server.odin:41:3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			if loc := result.PrimaryLocation(); loc.String() != tt.expectedFrame {
+				t.Errorf("PrimaryLocation() = %v, want %v", loc, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestSuggestionsAndOrder(t *testing.T) {
+	input := `/app/main.odin(7:2) Warning: 'x' declared but not used
+/app/main.odin(12:5) Error: Undeclared name: fooo
+	fooo()
+	^~~^
+	Suggestion: Did you mean 'foo'?
+/app/main.odin(12:5) Error: Undeclared name: fooo`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(results))
+	}
+	if results[0].Severity != errclean.SeverityError || len(results[0].Details) != 1 || results[0].Details[0] != "Suggestion: Did you mean 'foo'?" {
+		t.Errorf("error = %q, details %q", results[0].Message, results[0].Details)
+	}
+	if results[1].Type != "warning" || results[1].Severity != errclean.SeverityWarning {
+		t.Errorf("warning = %s (%s)", results[1].Type, results[1].Severity)
+	}
+}
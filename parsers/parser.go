@@ -15,3 +15,23 @@ type Parser interface {
 	// Parse processes the error text and returns a cleaned error structure
 	Parse(text string) *errclean.CleanedError
 }
+
+// MultiParser is implemented by parsers that can report several diagnostics
+// from a single input (e.g., one per failing test or compiler error).
+type MultiParser interface {
+	Parser
+
+	// ParseAll returns every diagnostic found in the error text, most
+	// relevant first. Parse should return the first element of this slice.
+	ParseAll(text string) []*errclean.CleanedError
+}
+
+// RootParser is implemented by parsers that read project files, such as
+// source maps, while parsing
+type RootParser interface {
+	MultiParser
+
+	// ParseAllIn is ParseAll with relative paths read from the project
+	// root. An empty root means the current directory.
+	ParseAllIn(text, root string) []*errclean.CleanedError
+}
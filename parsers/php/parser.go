@@ -0,0 +1,189 @@
+package php
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles PHP errors, uncaught exceptions with their stack traces and PHPUnit failures
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+var (
+	// Error line: "PHP Fatal error:  Uncaught Exception: broke in /app/src/Service.php:12"
+	// or "Warning: Undefined variable $x in /app/index.php on line 5"
+	errorPattern = regexp.MustCompile(`^(?:PHP )?(Fatal error|Parse error|Warning|Notice|Deprecated|Recoverable fatal error|Catchable fatal error): +(.*)$`)
+
+	// Uncaught exception: "Uncaught InvalidArgumentException: message in /app/src/User.php:12"
+	uncaughtPattern = regexp.MustCompile(`^Uncaught ([A-Za-z_\\][\w\\]*)(?:: (.*?))? in (\S+\.php):(\d+)$`)
+
+	// Location suffix of other errors: "... in /app/index.php on line 5"
+	onLinePattern = regexp.MustCompile(`^(.*) in (\S+) on line (\d+)$`)
+
+	// Chained exception in a trace: "Next RuntimeException: message in /app/src/Db.php:40"
+	nextPattern = regexp.MustCompile(`^Next ([A-Za-z_\\][\w\\]*)(?:: (.*?))? in (\S+\.php):(\d+)$`)
+
+	// Stack frame: "#0 /app/src/Controller.php(25): App\Service->run()" or "#3 [internal function]: ..."
+	framePattern = regexp.MustCompile(`^#\d+ (?:(\S+?)\((\d+)\)|\[internal function\]): (.*)$`)
+)
+
+func (p *Parser) Name() string {
+	return "php"
+}
+
+func (p *Parser) Detect(text string) int {
+	best := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "PHP ") && errorPattern.MatchString(trimmed):
+			return 100
+		case strings.HasPrefix(trimmed, "PHPUnit "):
+			return 100
+		case errorPattern.MatchString(trimmed) && strings.Contains(trimmed, ".php"):
+			return 95
+		case framePattern.MatchString(trimmed):
+			best = max(best, 90)
+		case phpunitHeaderPattern.MatchString(trimmed):
+			best = max(best, 70)
+		}
+	}
+	return best
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per PHPUnit failure or PHP error
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	lines := strings.Split(text, "\n")
+
+	results := parsePHPUnit(lines)
+	if len(results) == 0 {
+		results = parseErrors(lines)
+	}
+	if len(results) > 0 {
+		// Deprecations and notices usually come before the fatal error
+		errclean.SortBySeverity(results)
+		return results
+	}
+	return []*errclean.CleanedError{{Type: "error", Message: errclean.StripNoise(strings.TrimSpace(text))}}
+}
+
+// parseErrors returns one diagnostic per PHP error, with the stack trace of
+// uncaught exceptions. The CLI prints uncaught exceptions twice (the
+// "PHP " log line and the display line), so repeats are dropped.
+func parseErrors(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	seen := make(map[string]bool)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := errorPattern.FindStringSubmatch(trimmed); matches != nil {
+			result := parseError(matches[1], matches[2])
+			key := result.Type + "\x00" + result.Message + "\x00" + result.Location.String()
+			if seen[key] {
+				current = nil
+				continue
+			}
+			seen[key] = true
+			current = result
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch {
+		case framePattern.MatchString(trimmed):
+			current.Stack = append(current.Stack, parseFrame(trimmed))
+
+		case nextPattern.MatchString(trimmed):
+			// A chained exception: report the outermost one, which is
+			// printed last, and keep the earlier one as detail
+			matches := nextPattern.FindStringSubmatch(trimmed)
+			current.Details = append(current.Details, "Caused: "+current.Type+": "+current.Message)
+			current.Type = matches[1]
+			current.Message = errclean.StripNoise(matches[2])
+			current.Location = phpLocation(matches[3], matches[4])
+			current.Stack = []errclean.Frame{errclean.NewFrame(current.Location.String(), current.Location)}
+
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// parseError builds a diagnostic from the level and text of an error line
+func parseError(level, text string) *errclean.CleanedError {
+	text = strings.TrimSpace(text)
+	result := &errclean.CleanedError{Type: level}
+
+	switch level {
+	case "Warning", "Deprecated":
+		result.Severity = errclean.SeverityWarning
+	case "Notice":
+		result.Severity = errclean.SeverityNote
+	}
+
+	if matches := uncaughtPattern.FindStringSubmatch(text); matches != nil {
+		result.Type = matches[1]
+		result.Message = errclean.StripNoise(matches[2])
+		result.Location = phpLocation(matches[3], matches[4])
+	} else if matches := onLinePattern.FindStringSubmatch(text); matches != nil {
+		result.Message = errclean.StripNoise(matches[1])
+		result.Location = phpLocation(matches[2], matches[3])
+	} else {
+		result.Message = errclean.StripNoise(text)
+	}
+
+	if !result.Location.IsZero() {
+		result.Stack = []errclean.Frame{errclean.NewFrame(result.Location.String(), result.Location)}
+	}
+	return result
+}
+
+// parseFrame extracts the location and call from a numbered trace line
+func parseFrame(line string) errclean.Frame {
+	matches := framePattern.FindStringSubmatch(line)
+	if matches == nil {
+		return errclean.NewFrame(line, errclean.Location{})
+	}
+
+	text := strings.SplitN(line, " ", 2)[1]
+	var location errclean.Location
+	if matches[1] != "" {
+		location = phpLocation(matches[1], matches[2])
+	}
+	frame := errclean.NewFrame(text, location)
+	if location.IsZero() {
+		// "[internal function]": a callback invoked by the engine
+		frame.Kind = errclean.FrameRuntime
+	}
+	if i := strings.Index(matches[3], "("); i > 0 {
+		frame.Function = matches[3][:i]
+	}
+	return frame
+}
+
+func phpLocation(file, line string) errclean.Location {
+	lineNum, _ := strconv.Atoi(line)
+	return errclean.Location{File: file, Line: lineNum}
+}
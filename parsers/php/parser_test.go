@@ -0,0 +1,229 @@
+package php
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestPHPParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Uncaught exception",
+			input: `PHP Fatal error:  Uncaught InvalidArgumentException: User id must be positive in /var/www/app/src/UserService.php:12
+Stack trace:
+#0 /var/www/app/src/UserController.php(25): App\UserService->find(-1)
+#1 /var/www/app/vendor/laravel/framework/src/Illuminate/Routing/Controller.php(54): App\UserController->show()
+#2 {main}
+  thrown in /var/www/app/src/UserService.php on line 12`,
+			expectedType:  "InvalidArgumentException",
+			expectedMsg:   "User id must be positive",
+			expectedFrame: "/var/www/app/src/UserService.php:12",
+		},
+		{
+			name: "Uncaught error with namespace",
+			input: `PHP Fatal error:  Uncaught TypeError: App\add(): Argument #1 ($a) must be of type int, string given, called in /app/index.php on line 8 and defined in /app/src/math.php:3
+Stack trace:
+#0 /app/index.php(8): App\add('1', 2)
+#1 {main}
+  thrown in /app/src/math.php on line 3`,
+			expectedType:  "TypeError",
+			expectedMsg:   "must be of type int, string given",
+			expectedFrame: "/app/src/math.php:3",
+		},
+		{
+			name:          "Parse error",
+			input:         "PHP Parse error:  syntax error, unexpected token \"}\" in /app/index.php on line 14",
+			expectedType:  "Parse error",
+			expectedMsg:   `syntax error, unexpected token "}"`,
+			expectedFrame: "/app/index.php:14",
+		},
+		{
+			name:          "Warning without PHP prefix",
+			input:         "Warning: Undefined variable $name in /app/views/profile.php on line 3",
+			expectedType:  "Warning",
+			expectedMsg:   "Undefined variable $name",
+			expectedFrame: "/app/views/profile.php:3",
+		},
+		{
+			name: "PHPUnit failure",
+			input: `PHPUnit 10.5.0 by Sebastian Bergmann and contributors.
+
+F
+
+There was 1 failure:
+
+1) Tests\UserTest::testFullName
+Failed asserting that two strings are identical.
+--- Expected
++++ Actual
+@@ @@
+-'Jane Doe'
++'Jane'
+
+/app/tests/UserTest.php:15
+
+FAILURES!
+Tests: 1, Assertions: 1, Failures: 1.`,
+			expectedType:  "test failure",
+			expectedMsg:   "Failed asserting that two strings are identical.",
+			expectedFrame: "/app/tests/UserTest.php:15",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			if len(result.Stack) == 0 || result.Stack[0].Location.String() != tt.expectedFrame {
+				t.Errorf("Stack = %v, want first frame at %q", result.Stack, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestDuplicateCLIOutput(t *testing.T) {
+	// The CLI logs to stderr and displays on stdout; 2>&1 shows both
+	input := `PHP Fatal error:  Uncaught Exception: boom in /app/a.php:3
+Stack trace:
+#0 {main}
+  thrown in /app/a.php on line 3
+
+Fatal error: Uncaught Exception: boom in /app/a.php:3
+Stack trace:
+#0 {main}
+  thrown in /app/a.php on line 3`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(results))
+	}
+}
+
+func TestChainedExceptions(t *testing.T) {
+	input := `PHP Fatal error:  Uncaught PDOException: connection refused in /app/src/Db.php:10
+Stack trace:
+#0 /app/src/Db.php(10): PDO->__construct('mysql:host=db')
+#1 {main}
+
+Next RuntimeException: Database unavailable in /app/src/Repository.php:22
+Stack trace:
+#0 /app/index.php(5): App\Repository->all()
+#1 {main}
+  thrown in /app/src/Repository.php on line 22`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(results))
+	}
+
+	result := results[0]
+	if result.Type != "RuntimeException" || result.Message != "Database unavailable" {
+		t.Errorf("got %s: %s", result.Type, result.Message)
+	}
+	if len(result.Details) != 1 || result.Details[0] != "Caused: PDOException: connection refused" {
+		t.Errorf("Details = %q", result.Details)
+	}
+	if len(result.Stack) != 2 || result.Stack[1].Location.File != "/app/index.php" {
+		t.Errorf("Stack = %v", result.Stack)
+	}
+}
+
+func TestPHPUnitErrors(t *testing.T) {
+	input := `There were 2 errors:
+
+1) Tests\UserTest::testEmail
+Error: Call to a member function format() on null
+
+/app/src/User.php:20
+/app/tests/UserTest.php:22
+
+2) Tests\UserTest::testAge with data set #1 (-5)
+InvalidArgumentException: Age must be positive
+
+/app/src/User.php:31
+/app/tests/UserTest.php:40
+
+ERRORS!
+Tests: 4, Assertions: 2, Errors: 2.`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(results))
+	}
+
+	if results[0].Type != "Error" || results[0].Message != "Call to a member function format() on null" {
+		t.Errorf("first = %s: %s", results[0].Type, results[0].Message)
+	}
+	if results[1].Test != `Tests\UserTest::testAge` || results[1].Type != "InvalidArgumentException" {
+		t.Errorf("second = %s / %s", results[1].Test, results[1].Type)
+	}
+	if len(results[1].Stack) != 2 || results[1].Stack[0].Location.Line != 31 {
+		t.Errorf("second stack = %v", results[1].Stack)
+	}
+}
+
+func TestVendorFramesAreDependencies(t *testing.T) {
+	input := `PHP Fatal error:  Uncaught Exception: boom in /app/src/Job.php:7
+Stack trace:
+#0 [internal function]: App\Job->handle()
+#1 /app/vendor/laravel/framework/src/Illuminate/Container/BoundMethod.php(36): call_user_func_array(Array, Array)
+#2 /app/artisan(37): Illuminate\Foundation\Console\Kernel->handle()
+#3 {main}
+  thrown in /app/src/Job.php on line 7`
+
+	result := (&Parser{}).Parse(input)
+	classifier := errclean.NewClassifier("/app")
+	classifier.ClassifyFrames(result.Stack)
+
+	expected := []errclean.FrameKind{errclean.FrameUser, errclean.FrameRuntime, errclean.FrameDependency}
+	if len(result.Stack) < len(expected) {
+		t.Fatalf("expected at least %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+}
+
+func TestErrorsBeforeWarnings(t *testing.T) {
+	input := `PHP Deprecated:  Creation of dynamic property User::$age is deprecated in /app/src/User.php on line 8
+PHP Warning:  Undefined variable $name in /app/src/index.php on line 4
+PHP Fatal error:  Uncaught Error: Call to undefined function foo() in /app/src/index.php:12
+Stack trace:
+#0 {main}
+  thrown in /app/src/index.php on line 12`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d", len(results))
+	}
+	if results[0].Type != "Error" || results[0].Severity != errclean.SeverityError {
+		t.Errorf("first = %s (%s), want the fatal Error", results[0].Type, results[0].Severity)
+	}
+	if results[1].Type != "Deprecated" || results[2].Type != "Warning" {
+		t.Errorf("warnings = %s, %s, want them in input order", results[1].Type, results[2].Type)
+	}
+}
@@ -0,0 +1,91 @@
+package php
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// PHPUnit output patterns
+var (
+	// Section header: "There was 1 failure:", "There were 2 errors:"
+	phpunitHeaderPattern = regexp.MustCompile(`^There (?:was|were) \d+ (failure|error|warning|risky test)s?:$`)
+
+	// Test in a section: "1) Tests\UserTest::testFullName" or "... with data set #0 (1, 2)"
+	phpunitTestPattern = regexp.MustCompile(`^\d+\) (\S+::\S+)(?: with data set .*)?$`)
+
+	// Error raised in a test: "Error: Call to a member function format() on null"
+	phpunitErrorPattern = regexp.MustCompile(`^([A-Za-z_\\][\w\\]*): (.*)$`)
+
+	// Location line after the message: "/app/tests/UserTest.php:15"
+	phpunitFramePattern = regexp.MustCompile(`^(\S+\.php):(\d+)$`)
+)
+
+// parsePHPUnit returns one diagnostic per failing PHPUnit test, or nil if
+// the output has no PHPUnit failures
+func parsePHPUnit(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	section := ""
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := phpunitHeaderPattern.FindStringSubmatch(trimmed); matches != nil {
+			section = matches[1]
+			current = nil
+			continue
+		}
+		if section == "" {
+			continue
+		}
+		if trimmed == "FAILURES!" || trimmed == "ERRORS!" || strings.HasPrefix(trimmed, "Tests: ") || trimmed == "--" {
+			section = ""
+			current = nil
+			continue
+		}
+
+		if matches := phpunitTestPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{Type: "test failure", Test: matches[1]}
+			switch section {
+			case "warning", "risky test":
+				current.Type = "test " + section
+				current.Severity = errclean.SeverityWarning
+			}
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil || trimmed == "" {
+			continue
+		}
+
+		if matches := phpunitFramePattern.FindStringSubmatch(trimmed); matches != nil {
+			location := phpLocation(matches[1], matches[2])
+			current.Stack = append(current.Stack, errclean.NewFrame(trimmed, location))
+			continue
+		}
+
+		switch {
+		case current.Message == "":
+			if matches := phpunitErrorPattern.FindStringSubmatch(trimmed); section == "error" && matches != nil {
+				current.Type = matches[1]
+				current.Message = errclean.StripNoise(matches[2])
+			} else {
+				current.Message = errclean.StripNoise(trimmed)
+			}
+
+		case trimmed == "--- Expected" || trimmed == "+++ Actual" || strings.HasPrefix(trimmed, "@@ "):
+			// Diff headers; the -/+ lines that follow are the useful part
+
+		default:
+			current.Details = append(current.Details, errclean.StripNoise(trimmed))
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
@@ -53,7 +53,7 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 	lines := strings.Split(text, "\n")
 	result := &errclean.CleanedError{}
 
-	var stackFrames []string
+	var stackFrames []errclean.Frame
 	inTraceback := false
 
 	for i, line := range lines {
@@ -79,7 +79,7 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 				for j := i - 1; j >= 0 && j >= i-3; j-- {
 					prevLine := strings.TrimSpace(lines[j])
 					if strings.HasPrefix(prevLine, "File ") {
-						stackFrames = append(stackFrames, errclean.StripNoise(prevLine))
+						stackFrames = append(stackFrames, parseFrame(prevLine))
 						break
 					}
 				}
@@ -89,8 +89,7 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 
 		// File location: '  File "/path/to/file.py", line 42, in function'
 		if inTraceback && strings.HasPrefix(line, "  File ") {
-			frame := errclean.StripNoise(trimmed)
-			stackFrames = append(stackFrames, frame)
+			stackFrames = append(stackFrames, parseFrame(trimmed))
 			continue
 		}
 
@@ -121,6 +120,25 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 	}
 
 	result.Stack = errclean.DeduplicateFrames(stackFrames)
+	if len(result.Stack) > 0 {
+		// Python prints the innermost frame last
+		result.Location = result.Stack[len(result.Stack)-1].Location
+	}
 	result.Message = errclean.StripNoise(result.Message)
 	return result
 }
+
+// Traceback frame: 'File "/path/to/file.py", line 42, in function'
+var framePattern = regexp.MustCompile(`^File "([^"]+)", line (\d+)(?:, in (.+))?`)
+
+// parseFrame extracts the location and function from a traceback frame
+func parseFrame(line string) errclean.Frame {
+	matches := framePattern.FindStringSubmatch(line)
+	if matches == nil {
+		return errclean.NewFrame(line, errclean.Location{})
+	}
+
+	frame := errclean.NewFrame(line, errclean.ParseLocation(matches[1]+":"+matches[2]))
+	frame.Function = matches[3]
+	return frame
+}
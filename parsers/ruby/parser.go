@@ -0,0 +1,203 @@
+package ruby
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles Ruby exceptions, Rails request errors and RSpec/Minitest failures
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+// Ruby file names in backtraces, including templates and <internal:...> code
+const rubyFile = `(?:[^\s:]+\.(?:rb|erb|haml|slim|rake|ru|builder|jbuilder)|<internal:[^>]+>|-e)`
+
+var (
+	// Uncaught exception: "app/models/user.rb:12:in 'full_name': undefined method 'upcase' for nil (NoMethodError)"
+	// Ruby before 3.4 quotes methods as `full_name'
+	headerPattern = regexp.MustCompile("^(" + rubyFile + "):(\\d+):(?:in [`']([^']+)':)? (.*) \\(([A-Z][\\w:]*)\\)$")
+
+	// Backtrace frame: "from app/models/user.rb:5:in 'show'", "# ./spec/user_spec.rb:8:in ..." or
+	// a Rails-shortened gem frame: "actionpack (7.0.4) lib/action_controller/metal.rb:227:in 'dispatch'"
+	framePattern = regexp.MustCompile("^(?:from |# )?(?:([\\w-]+) \\(([\\w.]+)\\) )?(" + rubyFile + "):(\\d+)(?::in [`']([^']+)')?")
+
+	// Rails request error: "NoMethodError (undefined method `name' for nil:NilClass):"
+	railsHeaderPattern = regexp.MustCompile(`^([A-Z]\w*(?:::[A-Z]\w*)*) \((.+)\):$`)
+
+	// error_highlight marker under the offending code: "        ^^^^^^^"
+	caretPattern = regexp.MustCompile(`^\s*\^+\s*$`)
+)
+
+func (p *Parser) Name() string {
+	return "ruby"
+}
+
+func (p *Parser) Detect(text string) int {
+	best := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case headerPattern.MatchString(trimmed):
+			return 100
+		case trimmed == "Failure/Error:" || strings.HasPrefix(trimmed, "Failure/Error: "):
+			return 100
+		case minitestHeaderPattern.MatchString(trimmed):
+			best = max(best, 90)
+		case strings.HasPrefix(trimmed, "from ") && framePattern.MatchString(trimmed):
+			best = max(best, 90)
+		case railsHeaderPattern.MatchString(trimmed):
+			best = max(best, 60)
+		case framePattern.MatchString(trimmed) && strings.Contains(trimmed, ":in "):
+			best = max(best, 85)
+		}
+	}
+	return best
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per failing test, Rails request error or
+// uncaught exception
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	lines := strings.Split(text, "\n")
+
+	if results := parseRSpec(lines); len(results) > 0 {
+		return results
+	}
+	if results := parseMinitest(lines); len(results) > 0 {
+		return results
+	}
+	if results := parseExceptions(lines); len(results) > 0 {
+		return results
+	}
+	return []*errclean.CleanedError{{Type: "error", Message: errclean.StripNoise(strings.TrimSpace(text))}}
+}
+
+// parseExceptions returns one diagnostic per uncaught exception or Rails
+// request error, with its backtrace
+func parseExceptions(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	inMessage := false
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := headerPattern.FindStringSubmatch(trimmed); matches != nil {
+			lineNum, _ := strconv.Atoi(matches[2])
+			location := errclean.Location{File: matches[1], Line: lineNum}
+			frame := newFrame(matches[1]+":"+matches[2], location)
+			frame.Function = matches[3]
+
+			current = &errclean.CleanedError{
+				Type:     matches[5],
+				Message:  errclean.StripNoise(matches[4]),
+				Location: location,
+				Stack:    []errclean.Frame{frame},
+			}
+			results = append(results, current)
+			inMessage = true
+			continue
+		}
+
+		if matches := railsHeaderPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{
+				Type:    matches[1],
+				Message: errclean.StripNoise(matches[2]),
+			}
+			results = append(results, current)
+			inMessage = false
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if framePattern.MatchString(trimmed) && (strings.HasPrefix(trimmed, "from ") || strings.Contains(trimmed, ":in ")) {
+			current.Stack = append(current.Stack, parseFrame(trimmed))
+			inMessage = false
+			continue
+		}
+
+		// The rest of a multi-line message: did_you_mean suggestions and
+		// error_highlight snippets, whose code line we skip with its caret
+		if inMessage && trimmed != "" && !caretPattern.MatchString(line) {
+			if i+1 < len(lines) && caretPattern.MatchString(lines[i+1]) {
+				continue
+			}
+			current.Details = append(current.Details, errclean.StripNoise(trimmed))
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// parseFrame extracts the location and method from a backtrace line
+func parseFrame(line string) errclean.Frame {
+	matches := framePattern.FindStringSubmatch(line)
+	if matches == nil {
+		return newFrame(line, errclean.Location{})
+	}
+
+	file := matches[3]
+	if matches[1] != "" {
+		// Rails' backtrace cleaner prints gem frames relative to the gem;
+		// restore the gems/ path so they classify as dependencies
+		file = "gems/" + matches[1] + "-" + matches[2] + "/" + file
+	}
+
+	lineNum, _ := strconv.Atoi(matches[4])
+	text := strings.TrimPrefix(strings.TrimPrefix(line, "from "), "# ")
+	frame := newFrame(text, errclean.Location{File: file, Line: lineNum})
+	frame.Function = matches[5]
+	return frame
+}
+
+// Path fragments of installed gems and of the standard library, e.g.
+// /usr/local/lib/ruby/3.2.0/json/common.rb and <internal:kernel>. The
+// parser decides, not the classifier, because the same directory names
+// are user code in other languages.
+var (
+	gemPaths    = []string{"/gems/"}
+	stdlibPaths = []string{"/lib/ruby/", "<internal:"}
+)
+
+// newFrame creates a frame classified by the file it is in
+func newFrame(text string, location errclean.Location) errclean.Frame {
+	frame := errclean.NewFrame(text, location)
+	frame.Kind = frameKind(location.File)
+	return frame
+}
+
+// frameKind returns where the code of a file comes from. Gems installed
+// below the Ruby installation are dependencies, not the standard library.
+func frameKind(file string) errclean.FrameKind {
+	file = "/" + strings.TrimPrefix(filepath.ToSlash(file), "/")
+	for _, fragment := range gemPaths {
+		if strings.Contains(file, fragment) {
+			return errclean.FrameDependency
+		}
+	}
+	for _, fragment := range stdlibPaths {
+		if strings.Contains(file, fragment) {
+			return errclean.FrameRuntime
+		}
+	}
+	return errclean.FrameUser
+}
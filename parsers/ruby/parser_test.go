@@ -0,0 +1,181 @@
+package ruby
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestRubyParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Uncaught exception",
+			input: "app/models/user.rb:12:in `full_name': undefined method `upcase' for nil:NilClass (NoMethodError)\n" +
+				"\tfrom app/controllers/users_controller.rb:5:in `show'\n" +
+				"\tfrom /usr/local/bundle/gems/actionpack-7.0.4/lib/action_controller/metal/basic_implicit_render.rb:6:in `send_action'",
+			expectedType:  "NoMethodError",
+			expectedMsg:   "undefined method `upcase' for nil:NilClass",
+			expectedFrame: "app/models/user.rb:12",
+		},
+		{
+			name: "Ruby 3.4 quoting",
+			input: "script.rb:3:in 'Integer#/': divided by 0 (ZeroDivisionError)\n" +
+				"\tfrom script.rb:3:in 'Object#divide'\n" +
+				"\tfrom script.rb:6:in '<main>'",
+			expectedType:  "ZeroDivisionError",
+			expectedMsg:   "divided by 0",
+			expectedFrame: "script.rb:3",
+		},
+		{
+			name:          "Syntax error",
+			input:         "test.rb:3: syntax error, unexpected end-of-input, expecting `end' (SyntaxError)",
+			expectedType:  "SyntaxError",
+			expectedMsg:   "syntax error, unexpected end-of-input",
+			expectedFrame: "test.rb:3",
+		},
+		{
+			name: "Rails request error",
+			input: `Started GET "/users/1" for 127.0.0.1
+Completed 500 Internal Server Error in 12ms (ActiveRecord: 0.4ms)
+
+NoMethodError (undefined method ` + "`name'" + ` for nil:NilClass):
+
+app/controllers/users_controller.rb:12:in ` + "`show'" + `
+actionpack (7.0.4) lib/action_controller/metal/basic_implicit_render.rb:6:in ` + "`send_action'" + `
+rack (2.2.4) lib/rack/method_override.rb:24:in ` + "`call'",
+			expectedType:  "NoMethodError",
+			expectedMsg:   "undefined method `name' for nil:NilClass",
+			expectedFrame: "app/controllers/users_controller.rb:12",
+		},
+		{
+			name: "Minitest failure",
+			input: `  1) Failure:
+UserTest#test_full_name [test/models/user_test.rb:8]:
+Expected: "Jane Doe"
+  Actual: "Jane"
+
+1 runs, 1 assertions, 1 failures, 0 errors, 0 skips`,
+			expectedType:  "test failure",
+			expectedMsg:   `Expected: "Jane Doe"`,
+			expectedFrame: "test/models/user_test.rb:8",
+		},
+		{
+			name: "Minitest error",
+			input: `Error:
+UserTest#test_email:
+NoMethodError: undefined method ` + "`upcase'" + ` for nil:NilClass
+    app/models/user.rb:20:in ` + "`email'" + `
+    test/models/user_test.rb:14:in ` + "`block in <class:UserTest>'" + `
+
+bin/rails test test/models/user_test.rb:13`,
+			expectedType:  "NoMethodError",
+			expectedMsg:   "undefined method `upcase' for nil:NilClass",
+			expectedFrame: "app/models/user.rb:20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			if len(result.Stack) == 0 || !strings.Contains(result.Stack[0].Text, tt.expectedFrame) {
+				t.Errorf("Stack = %v, want first frame containing %q", result.Stack, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestRSpecFailures(t *testing.T) {
+	input := `..FF
+
+Failures:
+
+  1) User#full_name returns the full name
+     Failure/Error: expect(user.full_name).to eq("Jane Doe")
+
+       expected: "Jane Doe"
+            got: "Jane"
+
+       (compared using ==)
+     # ./spec/models/user_spec.rb:8:in ` + "`block (3 levels) in <top (required)>'" + `
+
+  2) User#email downcases the address
+     Failure/Error: user.email.downcase
+
+     NoMethodError:
+       undefined method ` + "`downcase'" + ` for nil:NilClass
+     # ./app/models/user.rb:20:in ` + "`email'" + `
+     # ./spec/models/user_spec.rb:14:in ` + "`block (3 levels) in <top (required)>'" + `
+
+Finished in 0.02 seconds (files took 0.3 seconds to load)
+4 examples, 2 failures
+
+Failed examples:
+
+rspec ./spec/models/user_spec.rb:7 # User#full_name returns the full name
+rspec ./spec/models/user_spec.rb:13 # User#email downcases the address`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 failures, got %d", len(results))
+	}
+
+	first := results[0]
+	if first.Test != "User#full_name returns the full name" || first.Type != "test failure" {
+		t.Errorf("first = %q / %q", first.Test, first.Type)
+	}
+	if first.Message != `expected: "Jane Doe"` || len(first.Details) != 2 || first.Details[0] != `got: "Jane"` {
+		t.Errorf("first message = %q, details = %q", first.Message, first.Details)
+	}
+
+	second := results[1]
+	if second.Type != "NoMethodError" || !strings.Contains(second.Message, "undefined method `downcase'") {
+		t.Errorf("second = %s: %s", second.Type, second.Message)
+	}
+	if len(second.Stack) != 2 || second.Stack[0].Location.File != "./app/models/user.rb" {
+		t.Errorf("second stack = %v", second.Stack)
+	}
+}
+
+func TestRailsFramesAreDependencies(t *testing.T) {
+	input := "NoMethodError (undefined method `name' for nil:NilClass):\n\n" +
+		"app/controllers/users_controller.rb:12:in `show'\n" +
+		"actionpack (7.0.4) lib/action_controller/metal/basic_implicit_render.rb:6:in `send_action'\n" +
+		"/usr/local/bundle/gems/rack-2.2.4/lib/rack/method_override.rb:24:in `call'\n" +
+		"/usr/local/lib/ruby/3.2.0/monitor.rb:202:in `synchronize'"
+
+	result := (&Parser{}).Parse(input)
+	classifier := errclean.NewClassifier("/app")
+	classifier.ClassifyFrames(result.Stack)
+
+	expected := []errclean.FrameKind{errclean.FrameUser, errclean.FrameDependency, errclean.FrameDependency, errclean.FrameRuntime}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %d", len(expected), len(result.Stack))
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package ruby
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Test runner output patterns
+var (
+	// RSpec failure: "  1) User#full_name returns the full name"
+	rspecFailurePattern = regexp.MustCompile(`^\s+\d+\) (.+)$`)
+
+	// Exception raised in an example: "NoMethodError:" followed by the indented message
+	exceptionClassPattern = regexp.MustCompile(`^([A-Z]\w*(?:::[A-Z]\w*)*):$`)
+
+	// Minitest block header: "  1) Failure:", "Error:"
+	minitestHeaderPattern = regexp.MustCompile(`^(?:\d+\) )?(Failure|Error):$`)
+
+	// Minitest test name: "UserTest#test_full_name [test/models/user_test.rb:8]:"
+	minitestNamePattern = regexp.MustCompile(`^(\S+#\S+?)(?: \[(.+):(\d+)\])?:$`)
+
+	// Minitest error: "NoMethodError: undefined method `upcase' for nil:NilClass"
+	minitestErrorPattern = regexp.MustCompile(`^([A-Z]\w*(?:::[A-Z]\w*)*): (.*)$`)
+)
+
+// parseRSpec returns one diagnostic per failing RSpec example, or nil if
+// the output has no RSpec failures
+func parseRSpec(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	inFailures := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "Failures:":
+			inFailures = true
+			continue
+		case strings.HasPrefix(trimmed, "Finished in ") || trimmed == "Failed examples:":
+			inFailures = false
+			current = nil
+			continue
+		}
+		if !inFailures {
+			continue
+		}
+
+		if matches := rspecFailurePattern.FindStringSubmatch(line); matches != nil {
+			current = &errclean.CleanedError{Type: "test failure", Test: errclean.StripNoise(matches[1])}
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil || trimmed == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "# ") && framePattern.MatchString(trimmed):
+			current.Stack = append(current.Stack, parseFrame(trimmed))
+
+		case strings.HasPrefix(trimmed, "Failure/Error:"):
+			// The failing line of the spec; the message follows
+
+		case exceptionClassPattern.MatchString(trimmed) && current.Message == "":
+			// An exception raised in the example rather than a failed expectation
+			current.Type = strings.TrimSuffix(trimmed, ":")
+
+		case current.Message == "":
+			current.Message = errclean.StripNoise(trimmed)
+
+		default:
+			// "got: ..." after "expected: ...", or the rest of a multi-line message
+			current.Details = append(current.Details, errclean.StripNoise(trimmed))
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// parseMinitest returns one diagnostic per Minitest failure or error, or
+// nil if the output has none
+func parseMinitest(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	expectName := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if minitestHeaderPattern.MatchString(trimmed) {
+			expectName = true
+			current = nil
+			continue
+		}
+
+		if expectName {
+			if trimmed == "" {
+				continue
+			}
+			expectName = false
+
+			matches := minitestNamePattern.FindStringSubmatch(trimmed)
+			if matches == nil {
+				continue
+			}
+			current = &errclean.CleanedError{Type: "test failure", Test: matches[1]}
+			if matches[2] != "" {
+				lineNum, _ := strconv.Atoi(matches[3])
+				current.Location = errclean.Location{File: matches[2], Line: lineNum}
+				current.Stack = []errclean.Frame{newFrame(matches[2]+":"+matches[3], current.Location)}
+			}
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		// A blank line or the rerun command ends the block
+		if trimmed == "" || strings.HasPrefix(trimmed, "bin/rails test ") {
+			current = nil
+			continue
+		}
+
+		if framePattern.MatchString(trimmed) && strings.Contains(trimmed, ":in ") {
+			current.Stack = append(current.Stack, parseFrame(trimmed))
+			continue
+		}
+
+		if current.Message == "" {
+			if matches := minitestErrorPattern.FindStringSubmatch(trimmed); matches != nil && !strings.HasPrefix(trimmed, "Expected") {
+				current.Type = matches[1]
+				current.Message = errclean.StripNoise(matches[2])
+			} else {
+				current.Message = errclean.StripNoise(trimmed)
+			}
+			continue
+		}
+
+		// "Actual: ..." after "Expected: ..."
+		current.Details = append(current.Details, errclean.StripNoise(trimmed))
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
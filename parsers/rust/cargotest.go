@@ -0,0 +1,88 @@
+package rust
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// cargo test output patterns
+var (
+	// Captured output of a failed test: "---- tests::foo stdout ----"
+	testSectionPattern = regexp.MustCompile(`^---- (\S+) stdout ----$`)
+
+	// Failed test listed in the "failures:" summary: "    tests::foo"
+	testSummaryPattern = regexp.MustCompile(`^    (\S+)$`)
+)
+
+// parseTestFailures returns one diagnostic per failed cargo test. It returns
+// nil if the output contains no test failures.
+func parseTestFailures(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	reported := make(map[string]bool)
+
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		// Each failing test's captured output runs until the next section
+		// or the "failures:" summary
+		if matches := testSectionPattern.FindStringSubmatch(trimmed); matches != nil {
+			end := i + 1
+			for end < len(lines) {
+				next := strings.TrimSpace(lines[end])
+				if testSectionPattern.MatchString(next) || next == "failures:" {
+					break
+				}
+				end++
+			}
+
+			results = append(results, parseTestSection(matches[1], lines[i+1:end]))
+			reported[matches[1]] = true
+			i = end - 1
+			continue
+		}
+
+		// Tests in the summary without captured output still failed
+		if trimmed == "failures:" {
+			for i+1 < len(lines) {
+				matches := testSummaryPattern.FindStringSubmatch(lines[i+1])
+				if matches == nil {
+					break
+				}
+				if !reported[matches[1]] {
+					results = append(results, &errclean.CleanedError{
+						Type:    "test failure",
+						Message: "test failed",
+						Test:    matches[1],
+					})
+					reported[matches[1]] = true
+				}
+				i++
+			}
+		}
+	}
+
+	return results
+}
+
+// parseTestSection parses the captured output of a single failed test
+func parseTestSection(name string, lines []string) *errclean.CleanedError {
+	result := parseLines(lines)
+	result.Test = name
+
+	// e.g. "note: test did not panic as expected" for #[should_panic] tests
+	if result.Message == "" {
+		result.Type = "test failure"
+		result.Message = "test failed"
+		for _, line := range lines {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "note:") && !strings.Contains(trimmed, "RUST_BACKTRACE") {
+				result.Message = strings.TrimSpace(strings.TrimPrefix(trimmed, "note:"))
+				break
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,108 @@
+package rust
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// rustc human-readable diagnostic patterns
+var (
+	// Diagnostic header: "error[E0382]: borrow of moved value" or "warning: unused variable: `x`"
+	headerPattern = regexp.MustCompile(`^(error|warning)(?:\[(\w+)\])?: (.+)$`)
+
+	// Summary headers that don't describe a problem in the code, including cargo's
+	// wrapper around a failed program: "process didn't exit successfully: `target/debug/app` (exit status: 101)"
+	summaryPattern = regexp.MustCompile(`^(?:aborting due to|could not compile|\d+ warnings? emitted|build failed|test failed, to rerun|process didn't exit successfully)|generated \d+ warnings?`)
+
+	// Lint note: "`#[warn(unused_variables)]` on by default"
+	lintPattern = regexp.MustCompile("^`#\\[(?:warn|deny|forbid)\\((\\w+)\\)\\]`")
+
+	// Sub-diagnostic: "= note: ..." or "= help: ..."
+	subDiagnosticPattern = regexp.MustCompile(`^= (note|help): (.+)$`)
+
+	// Inline label with a suggestion: "|         ^ help: if this is intentional, prefix it with an underscore: `_x`"
+	inlineHelpPattern = regexp.MustCompile(`^\d*\s*\|\s*[\^\-~]+\s+((?:help|note): .+)$`)
+)
+
+// severities maps rustc levels to diagnostic severities
+var severities = map[string]errclean.Severity{
+	"error":   errclean.SeverityError,
+	"warning": errclean.SeverityWarning,
+	"note":    errclean.SeverityNote,
+	"help":    errclean.SeverityHelp,
+}
+
+// parseCompilerBlocks returns one diagnostic per rustc error or warning,
+// with notes and help attached to their parent. It returns nil if the
+// output contains no rustc diagnostics.
+func parseCompilerBlocks(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		// Headers start at column 0; everything else belongs to the current block
+		if matches := headerPattern.FindStringSubmatch(line); matches != nil {
+			current = nil
+			if summaryPattern.MatchString(matches[3]) {
+				continue
+			}
+
+			current = &errclean.CleanedError{
+				Type:     matches[1],
+				Message:  errclean.StripNoise(matches[3]),
+				Severity: severities[matches[1]],
+			}
+			if matches[2] != "" {
+				current.Type = matches[2]
+			}
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "For more information") {
+			current = nil
+			continue
+		}
+
+		// File location: "--> src/main.rs:5:20"
+		if strings.HasPrefix(trimmed, "--> ") {
+			location := strings.TrimSpace(strings.TrimPrefix(trimmed, "--> "))
+			if current.Location.IsZero() {
+				current.Location = errclean.ParseLocation(location)
+			}
+			current.Stack = append(current.Stack, errclean.NewFrame(location, errclean.ParseLocation(location)))
+			continue
+		}
+
+		if matches := subDiagnosticPattern.FindStringSubmatch(trimmed); matches != nil {
+			if lint := lintPattern.FindStringSubmatch(matches[2]); lint != nil {
+				// Report lints by name, e.g. "unused_variables"
+				current.Type = lint[1]
+				continue
+			}
+			current.Details = append(current.Details, errclean.StripNoise(matches[1]+": "+matches[2]))
+			continue
+		}
+
+		if matches := inlineHelpPattern.FindStringSubmatch(trimmed); matches != nil {
+			current.Details = append(current.Details, errclean.StripNoise(matches[1]))
+			continue
+		}
+
+		// Standalone sub-diagnostics: "help: consider borrowing here: `&s`"
+		if strings.HasPrefix(line, "help: ") || strings.HasPrefix(line, "note: ") {
+			current.Details = append(current.Details, errclean.StripNoise(trimmed))
+		}
+	}
+
+	errclean.SortBySeverity(results)
+	return results
+}
@@ -0,0 +1,124 @@
+package rust
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// cargoMessage is a line of `cargo build --message-format=json` output
+type cargoMessage struct {
+	Reason  string      `json:"reason"`
+	Message *diagnostic `json:"message"`
+}
+
+// diagnostic is a rustc JSON diagnostic
+type diagnostic struct {
+	Message string `json:"message"`
+	Code    *struct {
+		Code string `json:"code"`
+	} `json:"code"`
+	Level    string       `json:"level"`
+	Spans    []span       `json:"spans"`
+	Children []diagnostic `json:"children"`
+}
+
+// span is a source region referenced by a diagnostic
+type span struct {
+	FileName             string  `json:"file_name"`
+	LineStart            int     `json:"line_start"`
+	ColumnStart          int     `json:"column_start"`
+	IsPrimary            bool    `json:"is_primary"`
+	Label                *string `json:"label"`
+	SuggestedReplacement *string `json:"suggested_replacement"`
+}
+
+// location returns the start of the span
+func (s span) location() errclean.Location {
+	return errclean.Location{File: s.FileName, Line: s.LineStart, Column: s.ColumnStart}
+}
+
+// isCargoJSON reports whether the line is a cargo compiler-message object
+func isCargoJSON(line string) bool {
+	return strings.HasPrefix(line, "{") && strings.Contains(line, `"reason":"compiler-message"`)
+}
+
+// parseCargoJSON returns one diagnostic per compiler error or warning in
+// cargo JSON output, errors first. Lines that are not compiler messages are
+// ignored.
+func parseCargoJSON(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if !isCargoJSON(line) {
+			continue
+		}
+
+		var msg cargoMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil || msg.Message == nil {
+			continue
+		}
+
+		// Summaries like "aborting due to previous error" have no spans
+		diag := msg.Message
+		if _, ok := severities[diag.Level]; !ok || len(diag.Spans) == 0 {
+			continue
+		}
+
+		results = append(results, convertDiagnostic(diag))
+	}
+
+	errclean.SortBySeverity(results)
+	return results
+}
+
+// convertDiagnostic maps a rustc diagnostic to a cleaned error. Span labels,
+// help and note children, and suggested replacements become details.
+func convertDiagnostic(diag *diagnostic) *errclean.CleanedError {
+	result := &errclean.CleanedError{
+		Type:     diag.Level,
+		Message:  errclean.StripNoise(diag.Message),
+		Severity: severities[diag.Level],
+	}
+	if diag.Code != nil && diag.Code.Code != "" {
+		result.Type = diag.Code.Code
+	}
+
+	for _, s := range diag.Spans {
+		if s.IsPrimary && result.Location.IsZero() {
+			result.Location = s.location()
+			result.Stack = append(result.Stack, errclean.NewFrame(result.Location.String(), result.Location))
+		}
+	}
+
+	// Primary label first, then secondary spans in source order
+	for _, primary := range []bool{true, false} {
+		for _, s := range diag.Spans {
+			if s.IsPrimary == primary && s.Label != nil && *s.Label != "" {
+				result.Details = append(result.Details,
+					errclean.StripNoise(fmt.Sprintf("%s: %s", s.location(), *s.Label)))
+			}
+		}
+	}
+
+	for _, child := range diag.Children {
+		// The lint name is already the diagnostic code
+		if lintPattern.MatchString(child.Message) {
+			continue
+		}
+
+		detail := child.Level + ": " + child.Message
+		for _, s := range child.Spans {
+			if s.SuggestedReplacement != nil {
+				detail += fmt.Sprintf(": `%s` (%s)", *s.SuggestedReplacement, s.location())
+				break
+			}
+		}
+		result.Details = append(result.Details, errclean.StripNoise(detail))
+	}
+
+	return result
+}
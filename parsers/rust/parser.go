@@ -30,11 +30,21 @@ func (p *Parser) Detect(text string) int {
 			return 100
 		}
 
+		// cargo --message-format=json output: definitive
+		if isCargoJSON(line) {
+			return 100
+		}
+
 		// Rust panic: high confidence
 		if strings.Contains(line, "panicked at") {
 			return 95
 		}
 
+		// cargo test failure section: high confidence
+		if testSectionPattern.MatchString(line) {
+			return 95
+		}
+
 		// Backtrace header
 		if strings.Contains(line, "stack backtrace:") {
 			return 90
@@ -50,13 +60,58 @@ func (p *Parser) Detect(text string) int {
 }
 
 func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per compiler error or warning or failed
+// cargo test, or the single panic found in the text. Errors come first.
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
 	lines := strings.Split(text, "\n")
+
+	if results := parseCargoJSON(lines); len(results) > 0 {
+		return results
+	}
+
+	if results := parseTestFailures(lines); len(results) > 0 {
+		return results
+	}
+
+	// cargo run prints compiler warnings before the program's panic
+	results := parseCompilerBlocks(lines)
+	if start := panicStart(lines); start >= 0 && len(results) > 0 {
+		results = append(results, parseLines(lines[start:]))
+		errclean.SortBySeverity(results)
+	}
+	if len(results) > 0 {
+		return results
+	}
+
+	return []*errclean.CleanedError{parseLines(lines)}
+}
+
+// panicStart returns the index of the first panic line, or -1 if the
+// program did not panic
+func panicStart(lines []string) int {
+	for i, line := range lines {
+		if strings.Contains(line, "panicked at") {
+			return i
+		}
+	}
+	return -1
+}
+
+// Rust 1.73+ panic header: "thread 'main' panicked at src/main.rs:2:5:"
+var modernPanicPattern = regexp.MustCompile(`panicked at ([^\s']+\.rs:\d+:\d+):$`)
+
+// parseLines handles compile errors, panics and backtraces
+func parseLines(lines []string) *errclean.CleanedError {
 	result := &errclean.CleanedError{}
 
-	var stackFrames []string
+	var stackFrames []errclean.Frame
 	inBacktrace := false
 
-	for _, line := range lines {
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
 		trimmed := strings.TrimSpace(line)
 
 		// Compile errors: "error[E0382]: borrow of moved value: `s`"
@@ -85,7 +140,11 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 				}
 			}
 			if location != "" {
-				stackFrames = append(stackFrames, errclean.StripNoise(location))
+				frame := errclean.NewFrame(location, errclean.ParseLocation(location))
+				if result.Location.IsZero() {
+					result.Location = frame.Location
+				}
+				stackFrames = append(stackFrames, frame)
 			}
 			continue
 		}
@@ -96,6 +155,19 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 				result.Type = "panic"
 			}
 
+			// Since Rust 1.73 the location comes first and the message
+			// follows on the next lines
+			if matches := modernPanicPattern.FindStringSubmatch(trimmed); matches != nil {
+				frame := errclean.NewFrame(matches[1], errclean.ParseLocation(matches[1]))
+				result.Location = frame.Location
+				stackFrames = append(stackFrames, frame)
+				message, details, next := panicMessage(lines, i+1)
+				result.Message = message
+				result.Details = append(result.Details, details...)
+				i = next - 1
+				continue
+			}
+
 			// Extract message between quotes
 			re := regexp.MustCompile(`panicked at '([^']+)'`)
 			matches := re.FindStringSubmatch(trimmed)
@@ -107,7 +179,10 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 			filePattern := regexp.MustCompile(`([^,]+\.rs:\d+:\d+)`)
 			fileMatches := filePattern.FindStringSubmatch(trimmed)
 			if len(fileMatches) > 1 {
-				stackFrames = append(stackFrames, errclean.StripNoise(fileMatches[1]))
+				location := strings.TrimSpace(fileMatches[1])
+				frame := errclean.NewFrame(location, errclean.ParseLocation(location))
+				result.Location = frame.Location
+				stackFrames = append(stackFrames, frame)
 			}
 			continue
 		}
@@ -121,13 +196,9 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 		// Stack frames (from RUST_BACKTRACE=1)
 		// Format: "  42: function_name" or "   0: rust_begin_unwind"
 		if inBacktrace && regexp.MustCompile(`^\d+:`).MatchString(trimmed) {
-			frame := errclean.StripNoise(trimmed)
-			// Skip std library internals unless they're the only frames
-			if (!strings.Contains(frame, "std::") &&
-				!strings.Contains(frame, "core::") &&
-				!strings.Contains(frame, "rust_begin_unwind")) || len(stackFrames) == 0 {
-				stackFrames = append(stackFrames, frame)
-			}
+			frame := errclean.NewFrame(trimmed, errclean.Location{})
+			frame.Function = strings.TrimSpace(strings.SplitN(trimmed, ":", 2)[1])
+			stackFrames = append(stackFrames, frame)
 			continue
 		}
 
@@ -135,10 +206,12 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 		if inBacktrace && strings.Contains(trimmed, "at ") && strings.Contains(trimmed, ".rs:") {
 			parts := strings.Split(trimmed, "at ")
 			if len(parts) >= 2 {
-				location := errclean.StripNoise(parts[1])
+				location := strings.TrimSpace(parts[1])
 				// Add to last frame if it doesn't have a location
-				if len(stackFrames) > 0 && !strings.Contains(stackFrames[len(stackFrames)-1], ".rs:") {
-					stackFrames[len(stackFrames)-1] += " " + location
+				if len(stackFrames) > 0 && stackFrames[len(stackFrames)-1].Location.IsZero() {
+					last := &stackFrames[len(stackFrames)-1]
+					last.Text += " " + errclean.StripNoise(location)
+					last.Location = errclean.ParseLocation(location)
 				}
 			}
 		}
@@ -148,3 +221,28 @@ func (p *Parser) Parse(text string) *errclean.CleanedError {
 	result.Message = errclean.StripNoise(result.Message)
 	return result
 }
+
+// panicMessage reads a multi-line panic message starting at lines[start].
+// The first line is the message, and any further lines (such as the
+// left/right values of a failed assert_eq!) are returned as details.
+// It also returns the index of the first line after the message.
+func panicMessage(lines []string, start int) (string, []string, int) {
+	var message string
+	var details []string
+
+	i := start
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "note:") ||
+			strings.HasPrefix(trimmed, "stack backtrace:") {
+			break
+		}
+		if message == "" {
+			message = trimmed
+		} else {
+			details = append(details, errclean.StripNoise(trimmed))
+		}
+	}
+
+	return message, details, i
+}
@@ -0,0 +1,259 @@
+package rust
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestRustParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name:          "Legacy panic",
+			input:         "thread 'main' panicked at 'index out of bounds: the len is 3 but the index is 5', src/main.rs:42:5",
+			expectedType:  "panic",
+			expectedMsg:   "index out of bounds: the len is 3 but the index is 5",
+			expectedFrame: "src/main.rs:42:5",
+		},
+		{
+			name: "Modern panic",
+			input: `thread 'main' panicked at src/main.rs:2:5:
+index out of bounds: the len is 3 but the index is 5
+note: run with ` + "`RUST_BACKTRACE=1`" + ` environment variable to display a backtrace`,
+			expectedType:  "panic",
+			expectedMsg:   "index out of bounds: the len is 3 but the index is 5",
+			expectedFrame: "src/main.rs:2:5",
+		},
+		{
+			name: "Compile error",
+			input: `error[E0382]: borrow of moved value: ` + "`s`" + `
+  --> src/main.rs:5:20`,
+			expectedType:  "E0382",
+			expectedMsg:   "borrow of moved value",
+			expectedFrame: "src/main.rs:5:20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			if len(result.Stack) == 0 || !strings.Contains(result.Stack[0].Text, tt.expectedFrame) {
+				t.Errorf("Stack = %v, want first frame containing %q", result.Stack, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestRustCargoTest(t *testing.T) {
+	parser := &Parser{}
+
+	input := `running 3 tests
+test tests::foo ... FAILED
+test tests::bar ... FAILED
+
+failures:
+
+---- tests::foo stdout ----
+thread 'tests::foo' panicked at src/lib.rs:10:9:
+assertion ` + "`left == right`" + ` failed
+  left: 4
+ right: 5
+note: run with ` + "`RUST_BACKTRACE=1`" + ` environment variable to display a backtrace
+
+---- tests::bar stdout ----
+note: test did not panic as expected
+
+failures:
+    tests::bar
+    tests::foo
+    tests::baz
+
+test result: FAILED. 0 passed; 3 failed; 0 ignored; 0 measured; 0 filtered out`
+
+	results := parser.ParseAll(input)
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	foo := results[0]
+	if foo.Test != "tests::foo" || foo.Type != "panic" || foo.Message != "assertion `left == right` failed" {
+		t.Errorf("unexpected first result: %+v", foo)
+	}
+	if strings.Join(foo.Details, "\n") != "left: 4\nright: 5" {
+		t.Errorf("Details = %v, want left/right values", foo.Details)
+	}
+
+	if results[1].Test != "tests::bar" || results[1].Message != "test did not panic as expected" {
+		t.Errorf("unexpected second result: %+v", results[1])
+	}
+
+	if results[2].Test != "tests::baz" || results[2].Type != "test failure" {
+		t.Errorf("unexpected third result: %+v", results[2])
+	}
+}
+
+func TestRustCargoJSON(t *testing.T) {
+	parser := &Parser{}
+
+	input := `{"reason":"compiler-artifact","package_id":"foo 0.1.0","fresh":true}
+{"reason":"compiler-message","package_id":"foo 0.1.0","message":{"rendered":"error[E0382]: borrow of moved value","children":[{"children":[],"code":null,"level":"help","message":"consider cloning the value","rendered":null,"spans":[{"file_name":"src/main.rs","line_start":4,"column_start":15,"is_primary":true,"label":null,"suggested_replacement":".clone()"}]}],"code":{"code":"E0382","explanation":null},"level":"error","message":"borrow of moved value: ` + "`s`" + `","spans":[{"file_name":"src/main.rs","line_start":3,"column_start":9,"is_primary":false,"label":"value moved here","suggested_replacement":null},{"file_name":"src/main.rs","line_start":5,"column_start":20,"is_primary":true,"label":"value borrowed here after move","suggested_replacement":null}]}}
+{"reason":"compiler-message","package_id":"foo 0.1.0","message":{"rendered":"error[E0425]: cannot find value","children":[],"code":{"code":"E0425","explanation":null},"level":"error","message":"cannot find value ` + "`x`" + ` in this scope","spans":[{"file_name":"src/lib.rs","line_start":2,"column_start":5,"is_primary":true,"label":"not found in this scope","suggested_replacement":null}]}}
+{"reason":"compiler-message","package_id":"foo 0.1.0","message":{"rendered":"error: aborting","children":[],"code":null,"level":"error","message":"aborting due to 2 previous errors","spans":[]}}
+{"reason":"build-finished","success":false}`
+
+	if score := parser.Detect(input); score < 100 {
+		t.Errorf("Detect() = %v, want 100", score)
+	}
+
+	results := parser.ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	first := results[0]
+	if first.Type != "E0382" || first.Location.String() != "src/main.rs:5:20" {
+		t.Errorf("unexpected first result: %+v", first)
+	}
+
+	details := strings.Join(first.Details, "\n")
+	for _, want := range []string{
+		"src/main.rs:5:20: value borrowed here after move",
+		"src/main.rs:3:9: value moved here",
+		"help: consider cloning the value: `.clone()` (src/main.rs:4:15)",
+	} {
+		if !strings.Contains(details, want) {
+			t.Errorf("Details should contain %q, got %v", want, first.Details)
+		}
+	}
+
+	if results[1].Type != "E0425" || results[1].Location.String() != "src/lib.rs:2:5" {
+		t.Errorf("unexpected second result: %+v", results[1])
+	}
+}
+
+func TestRustWarningsAndNotes(t *testing.T) {
+	parser := &Parser{}
+
+	input := `warning: unused variable: ` + "`x`" + `
+ --> src/main.rs:10:9
+  |
+10 |     let x = 5;
+   |         ^ help: if this is intentional, prefix it with an underscore: ` + "`_x`" + `
+   |
+   = note: ` + "`#[warn(unused_variables)]`" + ` on by default
+
+error[E0382]: borrow of moved value: ` + "`s`" + `
+  --> src/main.rs:5:20
+   |
+5  |     println!("{}", s);
+   |                    ^ value borrowed here after move
+   |
+   = note: this error originates in the macro ` + "`$crate::format_args_nl`" + `
+help: consider cloning the value if the performance cost is acceptable
+   |
+4  |     let s2 = s.clone();
+   |               ++++++++
+
+error: aborting due to 1 previous error; 1 warning emitted`
+
+	results := parser.ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	errResult := results[0]
+	if errResult.Type != "E0382" || errResult.Severity != errclean.SeverityError {
+		t.Errorf("unexpected first result: %+v", errResult)
+	}
+	details := strings.Join(errResult.Details, "\n")
+	for _, want := range []string{"note: this error originates", "help: consider cloning the value"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("Details should contain %q, got %v", want, errResult.Details)
+		}
+	}
+
+	warning := results[1]
+	if warning.Type != "unused_variables" || warning.Severity != errclean.SeverityWarning {
+		t.Errorf("unexpected second result: %+v", warning)
+	}
+	if warning.Location.String() != "src/main.rs:10:9" {
+		t.Errorf("Location = %v, want src/main.rs:10:9", warning.Location)
+	}
+	if len(warning.Details) != 1 || !strings.HasPrefix(warning.Details[0], "help: if this is intentional") {
+		t.Errorf("Details = %v, want inline help", warning.Details)
+	}
+}
+
+func TestRustCargoRunPanic(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name  string
+		input string
+		count int
+	}{
+		{
+			name: "Panic after warnings",
+			input: `warning: unused variable: ` + "`x`" + `
+ --> src/main.rs:10:9
+  |
+10 |     let x = 5;
+   |         ^
+   |
+   = note: ` + "`#[warn(unused_variables)]`" + ` on by default
+
+warning: ` + "`app`" + ` (bin "app") generated 1 warning
+    Finished ` + "`dev`" + ` profile [unoptimized + debuginfo] target(s) in 0.52s
+     Running ` + "`target/debug/app`" + `
+thread 'main' panicked at src/main.rs:4:5:
+index out of bounds: the len is 3 but the index is 5
+note: run with ` + "`RUST_BACKTRACE=1`" + ` environment variable to display a backtrace
+error: process didn't exit successfully: ` + "`target/debug/app`" + ` (exit status: 101)`,
+			count: 2,
+		},
+		{
+			name: "Panic only",
+			input: `     Running ` + "`target/debug/app`" + `
+thread 'main' panicked at src/main.rs:4:5:
+index out of bounds: the len is 3 but the index is 5
+note: run with ` + "`RUST_BACKTRACE=1`" + ` environment variable to display a backtrace
+error: process didn't exit successfully: ` + "`target/debug/app`" + ` (exit status: 101)`,
+			count: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := parser.ParseAll(tt.input)
+			if len(results) != tt.count {
+				t.Fatalf("got %d results, want %d", len(results), tt.count)
+			}
+
+			first := results[0]
+			if first.Type != "panic" || first.Message != "index out of bounds: the len is 3 but the index is 5" {
+				t.Errorf("unexpected first result: %+v", first)
+			}
+			if first.Location.String() != "src/main.rs:4:5" {
+				t.Errorf("Location = %v, want src/main.rs:4:5", first.Location)
+			}
+		})
+	}
+}
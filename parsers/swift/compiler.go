@@ -0,0 +1,71 @@
+package swift
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Compiler diagnostic: "/app/Sources/App/main.swift:12:5: error: cannot find 'foo' in scope"
+var diagnosticPattern = regexp.MustCompile(`^(.+\.swift):(\d+):(\d+): (error|warning|note): (.*)$`)
+
+// severities maps compiler levels to diagnostic severities
+var severities = map[string]errclean.Severity{
+	"error":   errclean.SeverityError,
+	"warning": errclean.SeverityWarning,
+}
+
+// parseDiagnostics returns one diagnostic per compiler error or warning,
+// with the notes that follow attached as details, or nil if the output has
+// none. swift build repeats diagnostics of files compiled in several jobs,
+// so repeats are dropped.
+func parseDiagnostics(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	seen := make(map[string]bool)
+
+	for _, line := range lines {
+		matches := diagnosticPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+
+		lineNum, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		location := errclean.Location{File: matches[1], Line: lineNum, Column: column}
+		message := errclean.StripNoise(matches[5])
+
+		if matches[4] == "note" {
+			// Notes explain the error before them: "note: did you mean 'food'?"
+			if current != nil {
+				current.Details = append(current.Details, "note: "+message)
+				current.Stack = append(current.Stack, newFrame(location.String(), location))
+			}
+			continue
+		}
+
+		key := matches[4] + "\x00" + message + "\x00" + location.String()
+		if seen[key] {
+			current = nil
+			continue
+		}
+		seen[key] = true
+
+		current = &errclean.CleanedError{
+			Type:     matches[4],
+			Message:  message,
+			Severity: severities[matches[4]],
+			Location: location,
+			Stack:    []errclean.Frame{newFrame(location.String(), location)},
+		}
+		results = append(results, current)
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	errclean.SortBySeverity(results)
+	return results
+}
@@ -0,0 +1,88 @@
+package swift
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Runtime crash patterns
+var (
+	// Runtime trap: "App/main.swift:12: Fatal error: Index out of range", or before
+	// Swift 5.4 "Fatal error: Unexpectedly found nil ...: file App/main.swift, line 12"
+	crashPattern = regexp.MustCompile(`^(?:(\S+\.swift):(\d+): )?(Fatal error|Precondition failed|Assertion failed)(?:: (.*?))?(?:: file (\S+\.swift), line (\d+))?$`)
+
+	// Backtrace frame before Swift 5.9: "4    App    0x000055d0c3b1e2f4 main + 52"
+	// and with the Swift 5.9 backtracer: "1 0x000055d0c3b1e2f4 main + 52 in App at /app/Sources/App/main.swift:12:5"
+	backtracePattern = regexp.MustCompile(`^\d+\s+(?:(\S+)\s+)?(?:\[\w+\] )*0x[0-9a-fA-F]+ (?:in )?(.+?)(?: \+ \d+)?(?: in (\S+))?(?: at (\S+?):(\d+)(?::(\d+))?)?$`)
+)
+
+// parseCrashes returns one diagnostic per runtime trap, with the backtrace
+// that follows it
+func parseCrashes(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := crashPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{
+				Type:    matches[3],
+				Message: errclean.StripNoise(matches[4]),
+			}
+			file, lineNum := matches[1], matches[2]
+			if file == "" {
+				file, lineNum = matches[5], matches[6]
+			}
+			if file != "" {
+				n, _ := strconv.Atoi(lineNum)
+				current.Location = errclean.Location{File: file, Line: n}
+				current.Stack = []errclean.Frame{newFrame(current.Location.String(), current.Location)}
+			}
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+		if matches := backtracePattern.FindStringSubmatch(trimmed); matches != nil {
+			current.Stack = append(current.Stack, parseFrame(matches))
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// parseFrame builds a frame from the matches of backtracePattern. Frames
+// of code without debug information have only a binary and a symbol.
+func parseFrame(matches []string) errclean.Frame {
+	binary := matches[1]
+	if binary == "" {
+		binary = matches[3]
+	}
+	function := matches[2]
+
+	var location errclean.Location
+	text := function
+	if binary != "" {
+		text = binary + " " + function
+	}
+	if matches[4] != "" {
+		lineNum, _ := strconv.Atoi(matches[5])
+		column, _ := strconv.Atoi(matches[6])
+		location = errclean.Location{File: matches[4], Line: lineNum, Column: column}
+		text += " at " + location.String()
+	}
+
+	frame := errclean.NewFrame(text, location)
+	frame.Function = function
+	frame.Kind = frameKind(binary, location.File)
+	return frame
+}
@@ -0,0 +1,91 @@
+package swift
+
+import (
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles Swift compiler diagnostics, runtime crashes and XCTest failures
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+func (p *Parser) Name() string {
+	return "swift"
+}
+
+func (p *Parser) Detect(text string) int {
+	best := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case diagnosticPattern.MatchString(trimmed), xctestPattern.MatchString(trimmed), swiftTestingPattern.MatchString(trimmed):
+			return 100
+		case crashPattern.MatchString(trimmed) && strings.Contains(trimmed, ".swift"):
+			return 100
+		case strings.HasPrefix(trimmed, "Test Suite '") || strings.HasPrefix(trimmed, "Test Case '"):
+			best = max(best, 90)
+		case crashPattern.MatchString(trimmed) || trimmed == "Current stack trace:" || strings.HasPrefix(trimmed, "💣 Program crashed: "):
+			best = max(best, 80)
+		}
+	}
+	return best
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per compiler error or warning or, when
+// the code built, per failing test and crash
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	lines := strings.Split(text, "\n")
+
+	if results := parseDiagnostics(lines); len(results) > 0 {
+		return results
+	}
+
+	// A crash during `swift test` ends the run, after any failures so far
+	results := append(parseTests(lines), parseCrashes(lines)...)
+	if len(results) > 0 {
+		return results
+	}
+	return []*errclean.CleanedError{{Type: "error", Message: errclean.StripNoise(strings.TrimSpace(text))}}
+}
+
+// Binaries of the Swift runtime and system libraries, which name the
+// frames of code without debug information, and the directory standard
+// library traps name their source in: "Swift/ContiguousArrayBuffer.swift".
+// The parser decides, not the classifier, because paths in other languages
+// can contain the same names.
+var (
+	runtimeBinaries = []string{"libswift", "libFoundation", "libdispatch", "libc.so"}
+	stdlibDir       = "Swift/"
+)
+
+// newFrame creates a frame classified by the file it is in
+func newFrame(text string, location errclean.Location) errclean.Frame {
+	frame := errclean.NewFrame(text, location)
+	frame.Kind = frameKind("", location.File)
+	return frame
+}
+
+// frameKind returns where the code of a frame comes from, by the binary
+// it is in or its source file
+func frameKind(binary, file string) errclean.FrameKind {
+	for _, prefix := range runtimeBinaries {
+		if strings.HasPrefix(binary, prefix) {
+			return errclean.FrameRuntime
+		}
+	}
+	if strings.HasPrefix(file, stdlibDir) && strings.HasSuffix(file, ".swift") {
+		return errclean.FrameRuntime
+	}
+	return errclean.FrameUser
+}
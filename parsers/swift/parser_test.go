@@ -0,0 +1,174 @@
+package swift
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestSwiftParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Compiler error",
+			input: `[1/3] Compiling App main.swift
+/app/Sources/App/main.swift:12:5: error: cannot find 'foo' in scope
+    foo()
+    ^~~
+error: fatalError`,
+			expectedType:  "error",
+			expectedMsg:   "cannot find 'foo' in scope",
+			expectedFrame: "/app/Sources/App/main.swift:12:5",
+		},
+		{
+			name:          "Fatal error",
+			input:         "App/main.swift:12: Fatal error: Unexpectedly found nil while unwrapping an Optional value",
+			expectedType:  "Fatal error",
+			expectedMsg:   "Unexpectedly found nil while unwrapping an Optional value",
+			expectedFrame: "App/main.swift:12",
+		},
+		{
+			name:          "Fatal error before Swift 5.4",
+			input:         "Fatal error: Unexpectedly found nil while unwrapping an Optional value: file App/main.swift, line 12",
+			expectedType:  "Fatal error",
+			expectedMsg:   "Unexpectedly found nil while unwrapping an Optional value",
+			expectedFrame: "App/main.swift:12",
+		},
+		{
+			name:          "Precondition failure without location",
+			input:         "Precondition failed: count must be positive\nCurrent stack trace:",
+			expectedType:  "Precondition failed",
+			expectedMsg:   "count must be positive",
+			expectedFrame: "",
+		},
+		{
+			name: "XCTest failure on Linux",
+			input: `Test Suite 'All tests' started at 2024-05-01 10:00:00.000
+Test Case 'AppTests.testAdd' started at 2024-05-01 10:00:00.001
+/app/Tests/AppTests/AppTests.swift:10: error: AppTests.testAdd : XCTAssertEqual failed: ("2") is not equal to ("3") - math is broken
+Test Case 'AppTests.testAdd' failed (0.002 seconds)`,
+			expectedType:  "test failure",
+			expectedMsg:   `XCTAssertEqual failed: ("2") is not equal to ("3") - math is broken`,
+			expectedFrame: "/app/Tests/AppTests/AppTests.swift:10",
+		},
+		{
+			name:          "swift-testing issue",
+			input:         "✘ Test add() recorded an issue at AppTests.swift:10:5: Expectation failed: (add(1, 1) → 2) == 3",
+			expectedType:  "test failure",
+			expectedMsg:   "Expectation failed",
+			expectedFrame: "AppTests.swift:10:5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			if loc := result.PrimaryLocation(); loc.String() != tt.expectedFrame {
+				t.Errorf("PrimaryLocation() = %v, want %v", loc, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestCompilerNotes(t *testing.T) {
+	input := `/app/Sources/App/main.swift:3:9: warning: initialization of immutable value 'x' was never used
+/app/Sources/App/main.swift:12:5: error: cannot find 'fooo' in scope
+/app/Sources/App/helpers.swift:4:6: note: did you mean 'foo'?
+/app/Sources/App/main.swift:12:5: error: cannot find 'fooo' in scope`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(results))
+	}
+
+	err := results[0]
+	if err.Type != "error" || len(err.Details) != 1 || err.Details[0] != "note: did you mean 'foo'?" {
+		t.Errorf("error = %s, details %q", err.Type, err.Details)
+	}
+	if len(err.Stack) != 2 || err.Stack[1].Location.File != "/app/Sources/App/helpers.swift" {
+		t.Errorf("Stack = %v", err.Stack)
+	}
+	if results[1].Severity != errclean.SeverityWarning {
+		t.Errorf("second severity = %s, want warning", results[1].Severity)
+	}
+}
+
+func TestCrashBacktrace(t *testing.T) {
+	input := `App/main.swift:8: Fatal error: Index out of range
+Current stack trace:
+0    libswiftCore.so                    0x00007f3a9e1c2e10 _swift_stdlib_reportFatalErrorInFile + 112
+1    libswiftCore.so                    0x00007f3a9de9c8a4 _assertionFailure(_:_:file:line:flags:) + 227
+2    App                                0x000055d0c3b1e2f4 $s3App5firstyS2iF + 52
+3    App                                0x000055d0c3b1e180 main + 64
+4    libc.so.6                          0x00007f3a9d3a1d90 __libc_start_main + 128`
+
+	result := (&Parser{}).Parse(input)
+	errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+
+	expected := []errclean.FrameKind{errclean.FrameUser, errclean.FrameRuntime, errclean.FrameRuntime, errclean.FrameUser, errclean.FrameUser, errclean.FrameRuntime}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+	if result.Stack[4].Function != "main" {
+		t.Errorf("Function = %q, want main", result.Stack[4].Function)
+	}
+}
+
+func TestNewBacktracerFrames(t *testing.T) {
+	input := `App/main.swift:8: Fatal error: Index out of range
+
+💣 Program crashed: Illegal instruction at 0x000055d0c3b1e2f4
+
+Thread 0 crashed:
+
+0 0x00007f3a9de9c8a4 _assertionFailure(_:_:file:line:flags:) + 227 in libswiftCore.so
+1 [ra] 0x000055d0c3b1e2f4 first(_:) + 52 in App at /app/Sources/App/main.swift:8:12`
+
+	result := (&Parser{}).Parse(input)
+	if len(result.Stack) != 3 {
+		t.Fatalf("expected 3 frames, got %v", result.Stack)
+	}
+
+	frame := result.Stack[2]
+	if frame.Function != "first(_:)" || frame.Location.String() != "/app/Sources/App/main.swift:8:12" {
+		t.Errorf("frame = %q at %v", frame.Function, frame.Location)
+	}
+}
+
+func TestStdlibTrapIsRuntime(t *testing.T) {
+	result := (&Parser{}).Parse("Swift/ContiguousArrayBuffer.swift:600: Fatal error: Index out of range")
+	if len(result.Stack) != 1 || result.Stack[0].Kind != errclean.FrameRuntime {
+		t.Errorf("Stack = %v, want the stdlib source as a runtime frame", result.Stack)
+	}
+
+	result = (&Parser{}).Parse("App/main.swift:12: Fatal error: Index out of range")
+	if len(result.Stack) != 1 || result.Stack[0].Kind != errclean.FrameUser {
+		t.Errorf("Stack = %v, want a user frame", result.Stack)
+	}
+}
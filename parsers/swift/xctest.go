@@ -0,0 +1,58 @@
+package swift
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Test runner output patterns
+var (
+	// XCTest failure on Linux: "/app/Tests/AppTests/AppTests.swift:10: error: AppTests.testAdd : XCTAssertEqual failed: ..."
+	// and on macOS: "... error: -[AppTests.AppTests testAdd] : XCTAssertEqual failed: ..."
+	xctestPattern = regexp.MustCompile(`^(.+\.swift):(\d+): error: (?:-\[(\S+) (\w+)\]|(\S+)) : (.*)$`)
+
+	// swift-testing issue: "✘ Test add() recorded an issue at AppTests.swift:10:5: Expectation failed: (2 == 3)"
+	swiftTestingPattern = regexp.MustCompile(`^✘ Test (.+?) recorded an issue at (.+\.swift):(\d+):(\d+): (.*)$`)
+)
+
+// parseTests returns one diagnostic per failed XCTest assertion or
+// swift-testing issue
+func parseTests(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		var result *errclean.CleanedError
+		if matches := xctestPattern.FindStringSubmatch(trimmed); matches != nil {
+			test := matches[5]
+			if test == "" {
+				test = matches[3] + "." + matches[4]
+			}
+			result = newFailure(test, matches[1], matches[2], "", matches[6])
+		} else if matches := swiftTestingPattern.FindStringSubmatch(trimmed); matches != nil {
+			result = newFailure(matches[1], matches[2], matches[3], matches[4], matches[5])
+		} else {
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func newFailure(test, file, line, column, message string) *errclean.CleanedError {
+	lineNum, _ := strconv.Atoi(line)
+	columnNum, _ := strconv.Atoi(column)
+	location := errclean.Location{File: file, Line: lineNum, Column: columnNum}
+
+	return &errclean.CleanedError{
+		Type:     "test failure",
+		Message:  errclean.StripNoise(message),
+		Test:     test,
+		Location: location,
+		Stack:    []errclean.Frame{newFrame(location.String(), location)},
+	}
+}
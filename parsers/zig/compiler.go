@@ -0,0 +1,118 @@
+package zig
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Compiler output patterns
+var (
+	// Compiler diagnostic: "src/main.zig:10:5: error: use of undeclared identifier 'foo'"
+	diagnosticPattern = regexp.MustCompile(`^(.+\.(?:zig|zon)):(\d+):(\d+): (error|note): (.*)$`)
+
+	// Reference trace entry below "referenced by:": "main: src/main.zig:20:5"
+	referencePattern = regexp.MustCompile(`^(\S+): (.+\.zig):(\d+):(\d+)$`)
+
+	// zig build summary of a failed step: "error: the following command failed with 1 compilation errors:"
+	buildFailurePattern = regexp.MustCompile(`^error: the following (?:build )?command (.*?):?$`)
+)
+
+// parseDiagnostics returns one diagnostic per compiler error, or nil if the
+// output has none. Notes become details, and the reference trace showing
+// how the compiler reached the code becomes the rest of the stack. zig
+// build prints an error again for each step that compiles the file, so
+// repeats are dropped.
+func parseDiagnostics(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+	seen := make(map[string]bool)
+	inReferences := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := diagnosticPattern.FindStringSubmatch(trimmed); matches != nil {
+			inReferences = false
+
+			lineNum, _ := strconv.Atoi(matches[2])
+			column, _ := strconv.Atoi(matches[3])
+			location := errclean.Location{File: matches[1], Line: lineNum, Column: column}
+			message := errclean.StripNoise(matches[5])
+
+			if matches[4] == "note" {
+				// Notes explain the error before them: "note: struct declared here"
+				if current != nil {
+					current.Details = append(current.Details, "note: "+message)
+					current.Stack = append(current.Stack, newFrame(location.String(), location))
+				}
+				continue
+			}
+
+			key := message + "\x00" + location.String()
+			if seen[key] {
+				current = nil
+				continue
+			}
+			seen[key] = true
+
+			current = &errclean.CleanedError{
+				Type:     "error",
+				Message:  message,
+				Location: location,
+				Stack:    []errclean.Frame{newFrame(location.String(), location)},
+			}
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch {
+		case trimmed == "referenced by:":
+			inReferences = true
+		case inReferences:
+			matches := referencePattern.FindStringSubmatch(trimmed)
+			if matches == nil {
+				// "remaining reference traces hidden; use '-freference-trace' to see all reference traces"
+				inReferences = strings.HasPrefix(trimmed, "remaining reference traces")
+				continue
+			}
+			lineNum, _ := strconv.Atoi(matches[3])
+			column, _ := strconv.Atoi(matches[4])
+			location := errclean.Location{File: matches[2], Line: lineNum, Column: column}
+
+			frame := newFrame(trimmed, location)
+			frame.Function = matches[1]
+			current.Stack = append(current.Stack, frame)
+		}
+	}
+
+	for _, result := range results {
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+	}
+	return results
+}
+
+// parseBuildFailure returns zig build's summary of a failed step, or nil
+// if the output has none. It is only reported when no compiler error
+// explains the failure, e.g. when a test binary or run step failed.
+func parseBuildFailure(lines []string) *errclean.CleanedError {
+	for i, line := range lines {
+		matches := buildFailurePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+
+		result := &errclean.CleanedError{Type: "build failed", Message: "command " + matches[1]}
+		if i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+			result.Details = append(result.Details, errclean.StripNoise(strings.TrimSpace(lines[i+1])))
+		}
+		return result
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package zig
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+// Runtime patterns
+var (
+	// Panic: "thread 12345 panic: index out of bounds: index 5, len 3"
+	threadPanicPattern = regexp.MustCompile(`^thread \d+ panic: (.*)$`)
+	panicPattern       = regexp.MustCompile(`^(?:thread \d+ )?panic: (.*)$`)
+
+	// Error returned from main, followed by its error return trace: "error: FileNotFound"
+	returnedErrorPattern = regexp.MustCompile(`^error: (\w+)$`)
+
+	// Stack trace frame: "/app/src/main.zig:12:22: 0x1035a1c in main (app)"
+	framePattern = regexp.MustCompile(`^(.+?):(\d+):(\d+): 0x[0-9a-fA-F]+ in (.+?) \(([^)]*)\)$`)
+)
+
+// parsePanics returns one diagnostic per panic or error returned from
+// main, with the stack trace that follows it. Each frame is followed by
+// the source line and a caret, and frames without debug information
+// ("???:?:?: 0x0 in ??? (???)") have nothing to show, so both are skipped.
+func parsePanics(lines []string) []*errclean.CleanedError {
+	var results []*errclean.CleanedError
+	var current *errclean.CleanedError
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if matches := panicPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{Type: "panic", Message: errclean.StripNoise(matches[1])}
+			results = append(results, current)
+			continue
+		}
+		if matches := returnedErrorPattern.FindStringSubmatch(trimmed); matches != nil {
+			current = &errclean.CleanedError{Type: "error", Message: matches[1]}
+			results = append(results, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+		if matches := framePattern.FindStringSubmatch(trimmed); matches != nil {
+			lineNum, _ := strconv.Atoi(matches[2])
+			column, _ := strconv.Atoi(matches[3])
+			location := errclean.Location{File: matches[1], Line: lineNum, Column: column}
+
+			frame := newFrame(matches[4]+" at "+location.String(), location)
+			frame.Function = matches[4]
+			current.Stack = append(current.Stack, frame)
+		}
+	}
+
+	// An error without a trace is not one main returned: "error: Foo" from a tool
+	var filtered []*errclean.CleanedError
+	for _, result := range results {
+		if result.Type == "error" && len(result.Stack) == 0 {
+			continue
+		}
+		result.Stack = errclean.DeduplicateFrames(result.Stack)
+		filtered = append(filtered, result)
+	}
+	return filtered
+}
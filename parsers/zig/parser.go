@@ -0,0 +1,95 @@
+package zig
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+	"github.com/XD637/err/registry"
+)
+
+// Parser handles Zig compiler diagnostics, panics and error return traces
+type Parser struct{}
+
+func init() {
+	registry.Register(&Parser{})
+}
+
+func (p *Parser) Name() string {
+	return "zig"
+}
+
+func (p *Parser) Detect(text string) int {
+	best := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case diagnosticPattern.MatchString(trimmed):
+			return 100
+		case threadPanicPattern.MatchString(trimmed):
+			return 100
+		case framePattern.MatchString(trimmed) && strings.Contains(trimmed, ".zig:"):
+			best = max(best, 95)
+		case strings.HasPrefix(trimmed, "error: the following command "), strings.HasPrefix(trimmed, "error: the following build command "):
+			best = max(best, 90)
+		case trimmed == "referenced by:":
+			best = max(best, 60)
+		}
+	}
+	return best
+}
+
+// Parse returns the most relevant error
+func (p *Parser) Parse(text string) *errclean.CleanedError {
+	return p.ParseAll(text)[0]
+}
+
+// ParseAll returns one diagnostic per compiler error or, when the code
+// built, per panic and error returned from main
+func (p *Parser) ParseAll(text string) []*errclean.CleanedError {
+	lines := strings.Split(text, "\n")
+
+	if results := parseDiagnostics(lines); len(results) > 0 {
+		return results
+	}
+	if results := parsePanics(lines); len(results) > 0 {
+		return results
+	}
+	if result := parseBuildFailure(lines); result != nil {
+		return []*errclean.CleanedError{result}
+	}
+	return []*errclean.CleanedError{{Type: "error", Message: errclean.StripNoise(strings.TrimSpace(text))}}
+}
+
+// Path fragments of the standard library, e.g. /usr/lib/zig/std/start.zig,
+// and the package cache, e.g. ~/.cache/zig/p/<hash>/src/root.zig. The
+// parser decides, not the classifier, because the same directory names
+// are user code in other languages.
+var (
+	stdlibPaths  = []string{"/lib/std/", "/zig/std/"}
+	packagePaths = []string{"zig/p/"}
+)
+
+// newFrame creates a frame classified by the file it is in
+func newFrame(text string, location errclean.Location) errclean.Frame {
+	frame := errclean.NewFrame(text, location)
+	frame.Kind = frameKind(location.File)
+	return frame
+}
+
+// frameKind returns where the code of a file comes from
+func frameKind(file string) errclean.FrameKind {
+	file = filepath.ToSlash(file)
+	for _, fragment := range stdlibPaths {
+		if strings.Contains(file, fragment) {
+			return errclean.FrameRuntime
+		}
+	}
+	for _, fragment := range packagePaths {
+		if strings.Contains(file, fragment) {
+			return errclean.FrameDependency
+		}
+	}
+	return errclean.FrameUser
+}
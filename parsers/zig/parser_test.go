@@ -0,0 +1,171 @@
+package zig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestZigParser(t *testing.T) {
+	parser := &Parser{}
+
+	tests := []struct {
+		name          string
+		input         string
+		expectedType  string
+		expectedMsg   string
+		expectedFrame string
+	}{
+		{
+			name: "Compile error",
+			input: `src/main.zig:10:5: error: use of undeclared identifier 'foo'
+    foo();
+    ^~~`,
+			expectedType:  "error",
+			expectedMsg:   "use of undeclared identifier 'foo'",
+			expectedFrame: "src/main.zig:10:5",
+		},
+		{
+			name: "zig build",
+			input: `install
+└─ install hello
+   └─ zig build-exe hello Debug native 1 errors
+src/main.zig:4:21: error: expected type 'u32', found 'i32'
+    const x: u32 = y;
+                   ^
+error: the following command failed with 1 compilation errors:
+/usr/bin/zig build-exe -ODebug --dep hello -Mroot=/app/src/main.zig
+Build Summary: 0/3 steps succeeded; 1 failed`,
+			expectedType:  "error",
+			expectedMsg:   "expected type 'u32', found 'i32'",
+			expectedFrame: "src/main.zig:4:21",
+		},
+		{
+			name: "Panic",
+			input: `thread 12345 panic: index out of bounds: index 5, len 3
+/app/src/main.zig:12:22: 0x1035a1c in getItem (app)
+    return items[5];
+                     ^
+/app/src/main.zig:20:18: 0x1035b2c in main (app)
+    const item = getItem(list);
+                 ^
+/usr/lib/zig/std/start.zig:524:37: 0x1034f5e in posixCallMainAndExit (app)
+            const result = root.main() catch |err| {
+                                    ^
+???:?:?: 0x0 in ??? (???)
+Aborted (core dumped)`,
+			expectedType:  "panic",
+			expectedMsg:   "index out of bounds: index 5, len 3",
+			expectedFrame: "/app/src/main.zig:12:22",
+		},
+		{
+			name: "Error return trace",
+			input: `error: FileNotFound
+/usr/lib/zig/std/fs/Dir.zig:842:5: 0x1037f2c in openFile (app)
+    return posix.openat(...);
+    ^
+/app/src/config.zig:8:18: 0x1035e31 in load (app)
+    const file = try std.fs.cwd().openFile(path, .{});
+                 ^`,
+			expectedType:  "error",
+			expectedMsg:   "FileNotFound",
+			expectedFrame: "/app/src/config.zig:8:18",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if confidence := parser.Detect(tt.input); confidence < 80 {
+				t.Errorf("Detect() = %d, want at least 80", confidence)
+			}
+
+			result := parser.Parse(tt.input)
+
+			if result.Type != tt.expectedType {
+				t.Errorf("Type = %v, want %v", result.Type, tt.expectedType)
+			}
+
+			if !strings.Contains(result.Message, tt.expectedMsg) {
+				t.Errorf("Message should contain %q, got %v", tt.expectedMsg, result.Message)
+			}
+
+			errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+			if loc := result.PrimaryLocation(); loc.String() != tt.expectedFrame {
+				t.Errorf("PrimaryLocation() = %v, want %v", loc, tt.expectedFrame)
+			}
+		})
+	}
+}
+
+func TestReferenceTrace(t *testing.T) {
+	input := `src/parser.zig:31:9: error: expected type '[]const u8', found '*const [5:0]u8'
+        "hello",
+        ^~~~~~~
+src/parser.zig:12:5: note: parameter type declared here
+    name: []const u8,
+    ^~~~
+referenced by:
+    parse: src/parser.zig:50:17
+    main: src/main.zig:7:5
+    remaining reference traces hidden; use '-freference-trace' to see all reference traces
+
+src/parser.zig:31:9: error: expected type '[]const u8', found '*const [5:0]u8'`
+
+	results := (&Parser{}).ParseAll(input)
+	if len(results) != 1 {
+		t.Fatalf("expected repeats to be dropped, got %d errors", len(results))
+	}
+
+	result := results[0]
+	if len(result.Details) != 1 || result.Details[0] != "note: parameter type declared here" {
+		t.Errorf("Details = %q", result.Details)
+	}
+
+	expected := []string{"src/parser.zig:31:9", "src/parser.zig:12:5", "src/parser.zig:50:17", "src/main.zig:7:5"}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, location := range expected {
+		if result.Stack[i].Location.String() != location {
+			t.Errorf("frame %d = %s, want %s", i, result.Stack[i].Location, location)
+		}
+	}
+	if result.Stack[3].Function != "main" {
+		t.Errorf("Function = %q, want main", result.Stack[3].Function)
+	}
+}
+
+func TestPanicFrameKinds(t *testing.T) {
+	input := `thread 1 panic: reached unreachable code
+/home/dev/.cache/zig/p/1220abcd/src/json.zig:88:13: 0x1036a10 in parseValue (app)
+/app/src/main.zig:20:18: 0x1035b2c in main (app)
+/opt/zig/lib/std/start.zig:524:37: 0x1034f5e in posixCallMainAndExit (app)
+???:?:?: 0x0 in ??? (???)`
+
+	result := (&Parser{}).Parse(input)
+	errclean.NewClassifier("/app").ClassifyFrames(result.Stack)
+
+	expected := []errclean.FrameKind{errclean.FrameDependency, errclean.FrameUser, errclean.FrameRuntime}
+	if len(result.Stack) != len(expected) {
+		t.Fatalf("expected %d frames, got %v", len(expected), result.Stack)
+	}
+	for i, kind := range expected {
+		if result.Stack[i].Kind != kind {
+			t.Errorf("frame %d (%s) kind = %s, want %s", i, result.Stack[i].Text, result.Stack[i].Kind, kind)
+		}
+	}
+}
+
+func TestBuildStepFailure(t *testing.T) {
+	input := `test
+└─ run test 1/2 passed, 1 failed
+error: the following command exited with error code 1:
+/app/.zig-cache/o/0123/test --listen=-
+Build Summary: 1/3 steps succeeded; 1 failed`
+
+	result := (&Parser{}).Parse(input)
+	if result.Type != "build failed" || result.Message != "command exited with error code 1" {
+		t.Errorf("got %s: %q", result.Type, result.Message)
+	}
+}
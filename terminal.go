@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Colors shared by the commands that print to a terminal
+const (
+	colorReset   = "\033[0m"
+	colorBold    = "\033[1m"
+	colorReverse = "\033[7m"
+	colorRed     = "\033[31m"
+	colorGreen   = "\033[32m"
+	colorYellow  = "\033[33m"
+	colorCyan    = "\033[36m"
+	colorGray    = "\033[90m"
+)
+
+// stdoutIsTerminal reports whether standard output is a terminal rather
+// than a file or pipe
+func stdoutIsTerminal() bool {
+	stat, err := os.Stdout.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
+}
+
+// terminal is the controlling terminal in raw mode. Input is usually piped
+// into err, so keys are read from /dev/tty rather than stdin.
+type terminal struct {
+	tty   *os.File
+	state string // stty settings to restore
+}
+
+// Escape sequences for full-screen output
+const (
+	escAltScreen  = "\033[?1049h"
+	escMainScreen = "\033[?1049l"
+	escHideCursor = "\033[?25l"
+	escShowCursor = "\033[?25h"
+	escHome       = "\033[H"
+	escClearLine  = "\033[K"
+	escClearBelow = "\033[J"
+)
+
+// openTerminal switches the controlling terminal to raw mode and the
+// alternate screen
+func openTerminal() (*terminal, error) {
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("interactive mode needs a Unix terminal")
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("interactive mode needs a terminal: %v", err)
+	}
+
+	state, err := stty(tty, "-g")
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+
+	t := &terminal{tty: tty, state: strings.TrimSpace(state)}
+	if err := t.enter(); err != nil {
+		t.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// enter puts the terminal in raw mode on the alternate screen
+func (t *terminal) enter() error {
+	if _, err := stty(t.tty, "raw", "-echo"); err != nil {
+		return err
+	}
+	fmt.Fprint(t.tty, escAltScreen+escHideCursor)
+	return nil
+}
+
+// leave restores the terminal settings and the main screen, e.g. while an
+// editor runs
+func (t *terminal) leave() {
+	fmt.Fprint(t.tty, escShowCursor+escMainScreen)
+	stty(t.tty, t.state)
+}
+
+// Close restores the terminal and releases it
+func (t *terminal) Close() {
+	t.leave()
+	t.tty.Close()
+}
+
+// size returns the terminal's rows and columns, with a fallback if the
+// size can't be read
+func (t *terminal) size() (rows, cols int) {
+	out, err := stty(t.tty, "size")
+	if err == nil {
+		fields := strings.Fields(out)
+		if len(fields) == 2 {
+			rows, _ = strconv.Atoi(fields[0])
+			cols, _ = strconv.Atoi(fields[1])
+		}
+	}
+	if rows <= 0 || cols <= 0 {
+		return 24, 80
+	}
+	return rows, cols
+}
+
+// readKey reads one key press, returning escape sequences as a whole
+func (t *terminal) readKey() (string, error) {
+	buf := make([]byte, 16)
+	n, err := t.tty.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
+
+// stty runs stty against the terminal
+func stty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("stty %s: %v", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,500 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/XD637/err/errclean"
+)
+
+const tuiKeys = "j/k move  J/K scroll  / search  s severity  g group  o open  q quit"
+
+// tuiRow is a row of the list: a group header or a diagnostic
+type tuiRow struct {
+	header string
+	result int // Index into results, -1 for headers
+}
+
+// tuiLine is a line of output with a single color
+type tuiLine struct {
+	text  string
+	color string
+}
+
+// tui is a full-screen browser for a large set of diagnostics
+type tui struct {
+	term    *terminal
+	results []*errclean.CleanedError
+	raw     []string // Original output, to show each diagnostic's block
+	root    string
+
+	minSeverity errclean.Severity
+	groupByType bool
+	query       string
+	searching   bool // Typing a search query
+
+	rows         []tuiRow
+	cursor       int // Selected row, always a diagnostic if there is one
+	offset       int // First visible row
+	detailOffset int
+	status       string
+}
+
+// runTUI implements `err -i`
+func runTUI(results []*errclean.CleanedError, input string, minSeverity errclean.Severity, root string) int {
+	term, err := openTerminal()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	defer term.Close()
+
+	t := &tui{
+		term:        term,
+		results:     results,
+		raw:         strings.Split(input, "\n"),
+		root:        root,
+		minSeverity: minSeverity,
+	}
+	t.rebuild()
+
+	for {
+		t.render()
+		key, err := term.readKey()
+		if err != nil {
+			return 1
+		}
+		if !t.handle(key) {
+			return 0
+		}
+	}
+}
+
+// handle processes a key press and reports whether to keep running
+func (t *tui) handle(key string) bool {
+	t.status = ""
+
+	if t.searching {
+		switch key {
+		case "\r", "\n":
+			t.searching = false
+		case "\033":
+			t.searching = false
+			t.query = ""
+		case "\x7f", "\b":
+			if r := []rune(t.query); len(r) > 0 {
+				t.query = string(r[:len(r)-1])
+			}
+		default:
+			if key >= " " && !strings.HasPrefix(key, "\033") {
+				t.query += key
+			}
+		}
+		t.rebuild()
+		return true
+	}
+
+	switch key {
+	case "q", "\x03", "\033":
+		return false
+	case "j", "\033[B":
+		t.move(1)
+	case "k", "\033[A":
+		t.move(-1)
+	case "\033[6~", " ":
+		t.move(t.listHeight())
+	case "\033[5~":
+		t.move(-t.listHeight())
+	case "J":
+		t.detailOffset++
+	case "K":
+		if t.detailOffset > 0 {
+			t.detailOffset--
+		}
+	case "/":
+		t.searching = true
+	case "s":
+		// Cycle the lowest severity shown: error, warning, note, help
+		t.minSeverity = (t.minSeverity + 1) % (errclean.SeverityHelp + 1)
+		t.rebuild()
+	case "g":
+		t.groupByType = !t.groupByType
+		t.rebuild()
+	case "o", "\r", "\n":
+		t.open()
+	}
+	return true
+}
+
+// rebuild filters and groups the diagnostics into rows, keeping the
+// selection on the same diagnostic when it is still shown
+func (t *tui) rebuild() {
+	selected := t.selected()
+	t.rows = tuiRows(t.results, t.minSeverity, t.query, t.groupByType)
+
+	t.cursor = -1
+	for i, row := range t.rows {
+		if row.result < 0 {
+			continue
+		}
+		if t.cursor < 0 || row.result == selected {
+			t.cursor = i
+		}
+		if row.result == selected {
+			break
+		}
+	}
+	t.detailOffset = 0
+	t.scroll()
+}
+
+// tuiRows returns the diagnostics that pass the filters, grouped by file
+// or by type. Groups are sorted by name, diagnostics by location.
+func tuiRows(results []*errclean.CleanedError, minSeverity errclean.Severity, query string, byType bool) []tuiRow {
+	query = strings.ToLower(query)
+	groups := make(map[string][]int)
+
+	for i, result := range results {
+		if !result.Severity.AtLeast(minSeverity) {
+			continue
+		}
+		loc := result.PrimaryLocation()
+		if query != "" {
+			text := strings.ToLower(strings.Join([]string{result.Type, result.Message, result.Test, loc.String()}, " "))
+			if !strings.Contains(text, query) {
+				continue
+			}
+		}
+
+		key := loc.File
+		if byType {
+			key = result.Type
+		}
+		if key == "" {
+			key = "(unknown)"
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var rows []tuiRow
+	for _, key := range keys {
+		indexes := groups[key]
+		sort.SliceStable(indexes, func(a, b int) bool {
+			la, lb := results[indexes[a]].PrimaryLocation(), results[indexes[b]].PrimaryLocation()
+			if la.File != lb.File {
+				return la.File < lb.File
+			}
+			return la.Line < lb.Line
+		})
+
+		rows = append(rows, tuiRow{header: fmt.Sprintf("%s (%d)", key, len(indexes)), result: -1})
+		for _, i := range indexes {
+			rows = append(rows, tuiRow{result: i})
+		}
+	}
+	return rows
+}
+
+// selected returns the index of the selected diagnostic, or -1
+func (t *tui) selected() int {
+	if t.cursor < 0 || t.cursor >= len(t.rows) {
+		return -1
+	}
+	return t.rows[t.cursor].result
+}
+
+// move moves the selection by n diagnostics, skipping group headers
+func (t *tui) move(n int) {
+	if t.cursor < 0 {
+		return
+	}
+
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		next := t.cursor + step
+		for next >= 0 && next < len(t.rows) && t.rows[next].result < 0 {
+			next += step
+		}
+		if next < 0 || next >= len(t.rows) {
+			break
+		}
+		t.cursor = next
+	}
+	t.detailOffset = 0
+	t.scroll()
+}
+
+// scroll keeps the selection, and its group header if possible, visible
+func (t *tui) scroll() {
+	height := t.listHeight()
+	if t.cursor < 0 {
+		t.offset = 0
+		return
+	}
+	if t.cursor-1 < t.offset {
+		t.offset = max(t.cursor-1, 0)
+	}
+	if t.cursor >= t.offset+height {
+		t.offset = t.cursor - height + 1
+	}
+}
+
+// listHeight is the number of list rows on screen; the detail pane gets the rest
+func (t *tui) listHeight() int {
+	rows, _ := t.term.size()
+	return max((rows-3)/2, 1)
+}
+
+// open opens the selected diagnostic's location in the editor
+func (t *tui) open() {
+	i := t.selected()
+	if i < 0 {
+		return
+	}
+	entry := newRunEntry(t.results[i], t.root)
+	if entry.File == "" {
+		t.status = "This diagnostic has no location"
+		return
+	}
+
+	t.term.leave()
+	cmd := editorCommand(userEditor(), entry)
+	cmd.Stdin = t.term.tty
+	cmd.Stdout = t.term.tty
+	cmd.Stderr = t.term.tty
+	err := cmd.Run()
+	if enterErr := t.term.enter(); enterErr != nil {
+		err = enterErr
+	}
+	if err != nil {
+		t.status = err.Error()
+	}
+}
+
+// render draws the title, list, detail pane and status line
+func (t *tui) render() {
+	rows, cols := t.term.size()
+	height := t.listHeight()
+	t.scroll()
+
+	var sb strings.Builder
+	sb.WriteString(escHome)
+
+	writeLine := func(line tuiLine) {
+		sb.WriteString(line.color)
+		sb.WriteString(truncate(line.text, cols))
+		sb.WriteString(colorReset)
+		sb.WriteString(escClearLine)
+		sb.WriteString("\r\n")
+	}
+
+	shown := 0
+	for _, row := range t.rows {
+		if row.result >= 0 {
+			shown++
+		}
+	}
+	group := "file"
+	if t.groupByType {
+		group = "type"
+	}
+	title := fmt.Sprintf("err  %d of %d diagnostics  group: %s  severity: %s+", shown, len(t.results), group, t.minSeverity)
+	if t.query != "" {
+		title += "  search: " + t.query
+	}
+	writeLine(tuiLine{title, colorReverse})
+
+	for i := t.offset; i < t.offset+height; i++ {
+		if i >= len(t.rows) {
+			writeLine(tuiLine{})
+			continue
+		}
+		writeLine(t.rowLine(i))
+	}
+
+	writeLine(tuiLine{strings.Repeat("─", cols), colorGray})
+
+	detail := t.detail()
+	if t.detailOffset > max(len(detail)-1, 0) {
+		t.detailOffset = max(len(detail)-1, 0)
+	}
+	detailHeight := rows - height - 3
+	for i := t.detailOffset; i < t.detailOffset+detailHeight; i++ {
+		if i < len(detail) {
+			writeLine(detail[i])
+		} else {
+			writeLine(tuiLine{})
+		}
+	}
+
+	// Status line, without a trailing newline so the screen doesn't scroll
+	status := tuiLine{tuiKeys, colorGray}
+	switch {
+	case t.searching:
+		status = tuiLine{"/" + t.query + "█", colorBold}
+	case t.status != "":
+		status = tuiLine{t.status, colorYellow}
+	}
+	sb.WriteString(status.color)
+	sb.WriteString(truncate(status.text, cols))
+	sb.WriteString(colorReset)
+	sb.WriteString(escClearBelow)
+
+	fmt.Fprint(t.term.tty, sb.String())
+}
+
+// rowLine formats a list row
+func (t *tui) rowLine(i int) tuiLine {
+	row := t.rows[i]
+	if row.result < 0 {
+		return tuiLine{row.header, colorBold}
+	}
+
+	result := t.results[row.result]
+	loc := result.PrimaryLocation()
+
+	position := ""
+	if loc.Line > 0 {
+		position = fmt.Sprintf("%d:%d", loc.Line, loc.Column)
+	}
+	label := result.Type
+	if t.groupByType {
+		label = loc.String()
+	}
+	text := fmt.Sprintf("  %-8s %-7s %s  %s", position, result.Severity, label, result.Message)
+	if result.Test != "" {
+		text += "  (" + result.Test + ")"
+	}
+
+	if i == t.cursor {
+		return tuiLine{text, colorReverse}
+	}
+	return tuiLine{text, severityColor(result.Severity)}
+}
+
+// detail returns the lines of the detail pane for the selected diagnostic
+func (t *tui) detail() []tuiLine {
+	i := t.selected()
+	if i < 0 {
+		return []tuiLine{{"No diagnostics match", colorGray}}
+	}
+	result := t.results[i]
+	color := severityColor(result.Severity)
+
+	var lines []tuiLine
+	if result.Test != "" {
+		lines = append(lines, tuiLine{"● " + result.Test, colorBold})
+	}
+	lines = append(lines, tuiLine{result.Type + ": " + result.Message, color + colorBold})
+	if loc := result.PrimaryLocation(); !loc.IsZero() {
+		lines = append(lines, tuiLine{"at " + loc.String(), colorGray})
+	}
+
+	for _, detail := range result.Details {
+		lines = append(lines, tuiLine{"  " + detail, ""})
+	}
+	for _, hint := range result.Hints {
+		lines = append(lines, tuiLine{"  → " + hint, colorCyan})
+	}
+
+	if result.Source != nil {
+		lines = append(lines, tuiLine{})
+		for _, line := range result.Source.Format() {
+			lines = append(lines, tuiLine{"  " + line, colorGray})
+		}
+	}
+
+	if len(result.Stack) > 0 {
+		lines = append(lines, tuiLine{}, tuiLine{"Stack:", colorBold})
+		for _, frame := range result.Stack {
+			lines = append(lines, tuiLine{fmt.Sprintf("  [%s] %s", frame.Kind, frame.Text), colorGray})
+		}
+	}
+
+	if block := rawBlock(t.raw, result); len(block) > 0 {
+		lines = append(lines, tuiLine{}, tuiLine{"Original:", colorBold})
+		for _, line := range block {
+			lines = append(lines, tuiLine{"  " + strings.ReplaceAll(line, "\t", "    "), colorGray})
+		}
+	}
+
+	return lines
+}
+
+// rawBlock finds the part of the original output a diagnostic came from:
+// the first line mentioning its message, location or type, extended to
+// the surrounding blank lines
+func rawBlock(lines []string, result *errclean.CleanedError) []string {
+	const maxContext = 12
+
+	var needles []string
+	if len(result.Message) >= 8 {
+		// Noise stripping may have changed the end of the message
+		needles = append(needles, result.Message[:min(len(result.Message), 40)])
+	}
+	if loc := result.PrimaryLocation(); loc.Line > 0 {
+		needles = append(needles, fmt.Sprintf("%s:%d", loc.File, loc.Line), fmt.Sprintf("%s(%d,", loc.File, loc.Line))
+	}
+	if result.Type != "" {
+		needles = append(needles, result.Type)
+	}
+
+	found := -1
+	for _, needle := range needles {
+		for i, line := range lines {
+			if strings.Contains(line, needle) {
+				found = i
+				break
+			}
+		}
+		if found >= 0 {
+			break
+		}
+	}
+	if found < 0 {
+		return nil
+	}
+
+	start, end := found, found+1
+	for start > 0 && found-start < maxContext && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	for end < len(lines) && end-found < maxContext && strings.TrimSpace(lines[end]) != "" {
+		end++
+	}
+	return lines[start:end]
+}
+
+func severityColor(severity errclean.Severity) string {
+	switch severity {
+	case errclean.SeverityError:
+		return colorRed
+	case errclean.SeverityWarning:
+		return colorYellow
+	default:
+		return colorCyan
+	}
+}
+
+// truncate shortens text to fit in width columns
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	if width <= 1 {
+		return string(runes[:max(width, 0)])
+	}
+	return string(runes[:width-1]) + "…"
+}
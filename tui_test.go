@@ -0,0 +1,94 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestTUIRows(t *testing.T) {
+	results := []*errclean.CleanedError{
+		{Type: "E0382", Message: "borrow of moved value", Location: errclean.Location{File: "src/main.rs", Line: 9}},
+		{Type: "unused_variables", Message: "unused variable: `x`", Severity: errclean.SeverityWarning, Location: errclean.Location{File: "src/main.rs", Line: 2}},
+		{Type: "E0308", Message: "mismatched types", Location: errclean.Location{File: "src/lib.rs", Line: 4}},
+		{Type: "panic", Message: "no location"},
+	}
+
+	tests := []struct {
+		name        string
+		minSeverity errclean.Severity
+		query       string
+		byType      bool
+		expected    []string
+	}{
+		{
+			name:        "by file",
+			minSeverity: errclean.SeverityWarning,
+			expected:    []string{"(unknown) (1)", "3", "src/lib.rs (1)", "2", "src/main.rs (2)", "1", "0"},
+		},
+		{
+			name:        "errors only",
+			minSeverity: errclean.SeverityError,
+			expected:    []string{"(unknown) (1)", "3", "src/lib.rs (1)", "2", "src/main.rs (1)", "0"},
+		},
+		{
+			name:        "search",
+			minSeverity: errclean.SeverityWarning,
+			query:       "MAIN.rs",
+			expected:    []string{"src/main.rs (2)", "1", "0"},
+		},
+		{
+			name:        "by type",
+			minSeverity: errclean.SeverityWarning,
+			query:       "E0",
+			byType:      true,
+			expected:    []string{"E0308 (1)", "2", "E0382 (1)", "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, row := range tuiRows(results, tt.minSeverity, tt.query, tt.byType) {
+				if row.result < 0 {
+					got = append(got, row.header)
+				} else {
+					got = append(got, string(rune('0'+row.result)))
+				}
+			}
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("tuiRows() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRawBlock(t *testing.T) {
+	input := `   Compiling app v0.1.0
+error[E0382]: borrow of moved value: ` + "`s`" + `
+ --> src/main.rs:5:20
+  |
+5 |     println!("{}", s);
+  |                    ^ value borrowed here after move
+
+error: could not compile ` + "`app`"
+
+	result := &errclean.CleanedError{
+		Type:     "E0382",
+		Message:  "borrow of moved value: `s`",
+		Location: errclean.Location{File: "src/main.rs", Line: 5, Column: 20},
+	}
+
+	block := rawBlock(strings.Split(input, "\n"), result)
+	if len(block) != 6 {
+		t.Fatalf("rawBlock() returned %d lines:\n%s", len(block), strings.Join(block, "\n"))
+	}
+	if !strings.HasPrefix(block[1], "error[E0382]") || !strings.Contains(block[5], "value borrowed here") {
+		t.Errorf("rawBlock() =\n%s", strings.Join(block, "\n"))
+	}
+
+	if block := rawBlock(strings.Split(input, "\n"), &errclean.CleanedError{Type: "missing", Message: "not in the output"}); block != nil {
+		t.Errorf("rawBlock() = %q, want nil", block)
+	}
+}
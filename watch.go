@@ -0,0 +1,252 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/XD637/err/errclean"
+)
+
+// watchIgnoredDirs hold dependencies and build output, which change when
+// the command runs rather than when the user saves
+var watchIgnoredDirs = map[string]bool{
+	"node_modules":     true,
+	"bower_components": true,
+	"vendor":           true,
+	"target":           true,
+	"dist":             true,
+	"build":            true,
+	"coverage":         true,
+	"__pycache__":      true,
+	"venv":             true,
+	".venv":            true,
+}
+
+// watchStatus tells how an error compares to the previous run
+type watchStatus int
+
+const (
+	watchFirst watchStatus = iota // First run, nothing to compare against
+	watchNew
+	watchUnchanged
+	watchFixed
+)
+
+// watchEntry is an error with its status since the previous run
+type watchEntry struct {
+	result *errclean.CleanedError
+	status watchStatus
+}
+
+// runWatch implements `err watch -- <command>`
+func runWatch(args []string) int {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := fs.Duration("interval", time.Second, "how often to check the tree for changes")
+	format := fs.String("format", "auto", formatUsage())
+	root := fs.String("root", "", "directory to watch and run the command in (default: current directory)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	command := fs.Args()
+	if len(command) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: err watch [-interval 1s] [-format FORMAT] -- <command>")
+		return 1
+	}
+
+	dir := *root
+	if dir == "" {
+		dir = "."
+	}
+
+	cleaner := NewCleaner(*format)
+	cleaner.Root = *root
+	cleaner.Frames = errclean.FrameFilter{UserOnly: true}
+	cleaner.Snippets = true
+	cleaner.Context = 1
+	engine, err := loadHints("", *root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	cleaner.Hints = engine
+
+	var previous []*errclean.CleanedError
+	for first := true; ; first = false {
+		// Snapshot before the run, so files saved while the command runs
+		// trigger the next one. Build output is in watchIgnoredDirs.
+		snapshot := snapshotTree(dir)
+		start := time.Now()
+		output, ok := runCommand(command, dir)
+		results := watchResults(cleaner, output, ok)
+
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("%s$ %s%s  %s(%s, %s)%s\n\n", colorBold, strings.Join(command, " "), colorReset,
+			colorGray, start.Format("15:04:05"), time.Since(start).Round(time.Millisecond), colorReset)
+		printWatchRun(os.Stdout, compareRuns(previous, results, first))
+		fmt.Printf("\n%sWatching %s for changes. Press Ctrl-C to stop.%s\n", colorGray, dir, colorReset)
+		previous = results
+
+		for {
+			time.Sleep(*interval)
+			next := snapshotTree(dir)
+			if treeChanged(snapshot, next) {
+				break
+			}
+		}
+	}
+}
+
+// watchResults cleans a run's output. Output that no parser recognizes is
+// only an error if the command failed: "ok" lines from a passing test run
+// are not.
+func watchResults(cleaner *Cleaner, output string, ok bool) []*errclean.CleanedError {
+	if strings.TrimSpace(output) == "" {
+		return nil
+	}
+
+	var results []*errclean.CleanedError
+	for _, result := range errclean.FilterSeverity(cleaner.CleanAll(output), errclean.SeverityWarning) {
+		if ok && result.Language == "" {
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// compareRuns marks each current error as new or unchanged, and lists the
+// previous run's errors that are gone as fixed. Errors of the first run
+// are not marked.
+func compareRuns(previous, current []*errclean.CleanedError, first bool) []watchEntry {
+	before := make(map[string]bool)
+	for _, result := range previous {
+		before[result.Fingerprint()] = true
+	}
+	after := make(map[string]bool)
+
+	var entries []watchEntry
+	for _, result := range current {
+		fingerprint := result.Fingerprint()
+		after[fingerprint] = true
+
+		status := watchNew
+		switch {
+		case first:
+			status = watchFirst
+		case before[fingerprint]:
+			status = watchUnchanged
+		}
+		entries = append(entries, watchEntry{result, status})
+	}
+
+	for _, result := range previous {
+		if !after[result.Fingerprint()] {
+			entries = append(entries, watchEntry{result, watchFixed})
+		}
+	}
+	return entries
+}
+
+// printWatchRun prints the errors of a run with their markers
+func printWatchRun(w io.Writer, entries []watchEntry) {
+	remaining := 0
+	for i, entry := range entries {
+		if entry.status == watchFixed {
+			// Fixed errors come last; separate them from the remaining ones
+			if i > 0 && entries[i-1].status != watchFixed {
+				fmt.Fprintln(w)
+			}
+			loc := ""
+			if l := entry.result.PrimaryLocation(); !l.IsZero() {
+				loc = "  " + l.String()
+			}
+			fmt.Fprintf(w, "%s✓ fixed%s  %s%s: %s%s%s\n", colorGreen, colorReset, colorGray, entry.result.Type, entry.result.Message, loc, colorReset)
+			continue
+		}
+
+		remaining++
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		switch entry.status {
+		case watchNew:
+			fmt.Fprintf(w, "%s+ new%s\n", colorYellow, colorReset)
+		case watchUnchanged:
+			fmt.Fprintf(w, "%s= unchanged%s\n", colorGray, colorReset)
+		}
+		output := entry.result.Format()
+		fmt.Fprint(w, output)
+		if !strings.HasSuffix(output, "\n") {
+			fmt.Fprintln(w)
+		}
+	}
+
+	if remaining == 0 {
+		fmt.Fprintf(w, "%s✓ No errors%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Fprintf(w, "\n%s%d error(s)%s\n", colorRed, remaining, colorReset)
+	}
+}
+
+// runCommand runs the command and returns its combined output and whether
+// it succeeded. A single argument is run through the shell, so
+// `err watch -- "make && ./app"` works.
+func runCommand(command []string, dir string) (string, bool) {
+	var cmd *exec.Cmd
+	if len(command) == 1 {
+		cmd = shellCommand(command[0])
+	} else {
+		cmd = exec.Command(command[0], command[1:]...)
+	}
+	cmd.Dir = dir
+
+	output, err := cmd.CombinedOutput()
+	if err != nil && len(output) == 0 {
+		return err.Error(), false
+	}
+	return string(output), err == nil
+}
+
+// snapshotTree records the modification time and size of every file in the
+// tree. Polling works everywhere, including network and container mounts
+// where file system events are unreliable.
+func snapshotTree(root string) map[string]string {
+	files := make(map[string]string)
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (watchIgnoredDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			files[path] = fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+		}
+		return nil
+	})
+	return files
+}
+
+// treeChanged reports whether a file was added, removed or modified
+func treeChanged(before, after map[string]string) bool {
+	if len(before) != len(after) {
+		return true
+	}
+	for path, stamp := range after {
+		if before[path] != stamp {
+			return true
+		}
+	}
+	return false
+}
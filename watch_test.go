@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XD637/err/errclean"
+)
+
+func TestCompareRuns(t *testing.T) {
+	moved := func(line int) *errclean.CleanedError {
+		return &errclean.CleanedError{Type: "E0382", Message: "borrow of moved value: `s`", Location: errclean.Location{File: "src/main.rs", Line: line}}
+	}
+	unused := &errclean.CleanedError{Type: "unused_variables", Message: "unused variable: `x`", Location: errclean.Location{File: "src/main.rs", Line: 2}}
+	mismatch := &errclean.CleanedError{Type: "E0308", Message: "mismatched types", Location: errclean.Location{File: "src/lib.rs", Line: 4}}
+
+	// First run: nothing to compare against
+	for _, entry := range compareRuns(nil, []*errclean.CleanedError{moved(5)}, true) {
+		if entry.status != watchFirst {
+			t.Errorf("first run status = %d, want first", entry.status)
+		}
+	}
+
+	// The moved-value error shifted down a line, the unused variable was
+	// fixed and a type mismatch appeared
+	entries := compareRuns(
+		[]*errclean.CleanedError{moved(5), unused},
+		[]*errclean.CleanedError{moved(6), mismatch},
+		false,
+	)
+
+	expected := []struct {
+		typ    string
+		status watchStatus
+	}{
+		{"E0382", watchUnchanged},
+		{"E0308", watchNew},
+		{"unused_variables", watchFixed},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i, want := range expected {
+		if entries[i].result.Type != want.typ || entries[i].status != want.status {
+			t.Errorf("entry %d = %s/%d, want %s/%d", i, entries[i].result.Type, entries[i].status, want.typ, want.status)
+		}
+	}
+
+	// After a clean run, the next error is new
+	entries = compareRuns(nil, []*errclean.CleanedError{mismatch}, false)
+	if entries[0].status != watchNew {
+		t.Errorf("status after a clean run = %d, want new", entries[0].status)
+	}
+}
+
+func TestWatchResults(t *testing.T) {
+	cleaner := NewCleaner("auto")
+
+	passing := "ok  \tgithub.com/example/app\t0.004s\n"
+	if results := watchResults(cleaner, passing, true); len(results) != 0 {
+		t.Errorf("passing run reported %d errors: %s", len(results), results[0].Message)
+	}
+	if results := watchResults(cleaner, "Segmentation fault\n", false); len(results) != 1 {
+		t.Errorf("failing run reported %d errors, want 1", len(results))
+	}
+}
+
+func TestPrintWatchRunMarkers(t *testing.T) {
+	err := func(code string) *errclean.CleanedError {
+		return &errclean.CleanedError{Type: code, Message: "message " + code}
+	}
+
+	var first strings.Builder
+	printWatchRun(&first, []watchEntry{{err("E0382"), watchFirst}})
+	for _, marker := range []string{"+ new", "= unchanged", "✓ fixed"} {
+		if strings.Contains(first.String(), marker) {
+			t.Errorf("first run output contains %q:\n%s", marker, first.String())
+		}
+	}
+
+	var out strings.Builder
+	printWatchRun(&out, []watchEntry{
+		{err("E0382"), watchUnchanged},
+		{err("E0308"), watchNew},
+		{err("E0425"), watchFixed},
+	})
+	expected := []string{
+		"= unchanged" + colorReset + "\n" + err("E0382").Format(),
+		"+ new" + colorReset + "\n" + err("E0308").Format(),
+		"✓ fixed" + colorReset + "  " + colorGray + "E0425: message E0425",
+		"2 error(s)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output should contain %q:\n%s", want, out.String())
+		}
+	}
+}